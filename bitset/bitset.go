@@ -0,0 +1,250 @@
+// Package bitset provides a set of small non-negative integers stored as bits
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+const wordBits = 64
+
+var ErrInvalidData = errors.New("data length is not a multiple of 8")
+
+// BitSet is a set of non-negative integers. Every possible value takes one bit,
+// so memory depends on the biggest value, not on the number of elements.
+// The zero value is an empty set ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// New creates a new bit set.
+func New(initial ...uint) *BitSet {
+	b := &BitSet{}
+	b.Insert(initial...)
+	return b
+}
+
+// Insert adds elements to the set.
+func (b *BitSet) Insert(elements ...uint) {
+	for _, e := range elements {
+		w := int(e / wordBits)
+		if w >= len(b.words) {
+			b.words = append(b.words, make([]uint64, w+1-len(b.words))...)
+		}
+		b.words[w] |= 1 << (e % wordBits)
+	}
+}
+
+// Remove removes elements from the set. Missing elements do nothing.
+func (b *BitSet) Remove(elements ...uint) {
+	for _, e := range elements {
+		if w := int(e / wordBits); w < len(b.words) {
+			b.words[w] &^= 1 << (e % wordBits)
+		}
+	}
+	b.trim()
+}
+
+// trim drops empty words at the end.
+func (b *BitSet) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
+
+// Contains checks is element in the set or not.
+func (b *BitSet) Contains(element uint) bool {
+	w := int(element / wordBits)
+	return w < len(b.words) && b.words[w]&(1<<(element%wordBits)) != 0
+}
+
+// Count returns number of items in the set. It takes O(max/64).
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Len returns number of items in the set, same as Count.
+func (b *BitSet) Len() int {
+	return b.Count()
+}
+
+// IsEmpty returns 'true' if there are no elements.
+func (b *BitSet) IsEmpty() bool {
+	return len(b.words) == 0
+}
+
+// NextSet returns the smallest element which is not less than i.
+func (b *BitSet) NextSet(i uint) (value uint, exists bool) {
+	w := int(i / wordBits)
+	if w >= len(b.words) {
+		return
+	}
+	word := b.words[w] >> (i % wordBits)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return uint(w)*wordBits + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return
+}
+
+// Seq returns value-iterator in ascending order.
+func (b *BitSet) Seq() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				t := bits.TrailingZeros64(w)
+				if !yield(uint(i)*wordBits + uint(t)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Clear removes all elements.
+func (b *BitSet) Clear() {
+	b.words = nil
+}
+
+// Clone returns a new set with same elements.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: slices.Clone(b.words)}
+}
+
+// Equal return true if both sets have same elements.
+func (b *BitSet) Equal(other *BitSet) bool {
+	return slices.Equal(b.words, other.words)
+}
+
+// SubsetOf checks is this set a subset of "other".
+func (b *BitSet) SubsetOf(other *BitSet) bool {
+	if len(b.words) > len(other.words) {
+		return false
+	}
+	for i, w := range b.words {
+		if w&^other.words[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ProperSubsetOf checks is this set a proper subset of "other".
+func (b *BitSet) ProperSubsetOf(other *BitSet) bool {
+	return b.SubsetOf(other) && !b.Equal(other)
+}
+
+// IsDisjoint checks that sets have no common elements.
+func (b *BitSet) IsDisjoint(other *BitSet) bool {
+	for i := range min(len(b.words), len(other.words)) {
+		if b.words[i]&other.words[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionWith adds all elements of "other" to this set.
+func (b *BitSet) UnionWith(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// IntersectWith keeps only elements which are in "other".
+func (b *BitSet) IntersectWith(other *BitSet) {
+	if len(b.words) > len(other.words) {
+		b.words = b.words[:len(other.words)]
+	}
+	for i := range b.words {
+		b.words[i] &= other.words[i]
+	}
+	b.trim()
+}
+
+// DifferenceWith removes all elements of "other" from this set.
+func (b *BitSet) DifferenceWith(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+	b.trim()
+}
+
+// SymmetricDifferenceWith keeps elements which are only in one of sets.
+func (b *BitSet) SymmetricDifferenceWith(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	b.trim()
+}
+
+// Union returns a new set with elements of both sets.
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.UnionWith(other)
+	return res
+}
+
+// Intersection returns a new set with elements which are in both sets.
+func (b *BitSet) Intersection(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.IntersectWith(other)
+	return res
+}
+
+// Difference returns a new set with elements which are not in "other".
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.DifferenceWith(other)
+	return res
+}
+
+// SymmetricDifference returns a new set with elements which are only in one of sets.
+func (b *BitSet) SymmetricDifference(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.SymmetricDifferenceWith(other)
+	return res
+}
+
+// MarshalBinary encodes the set as little-endian 64-bit words.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(b.words)*8)
+	for _, w := range b.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces elements by the result of MarshalBinary.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return ErrInvalidData
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	b.words = words
+	b.trim()
+	return nil
+}
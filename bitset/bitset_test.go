@@ -0,0 +1,166 @@
+package bitset
+
+import (
+	"github.com/stretchr/testify/suite"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type bitSetTestSuite struct {
+	suite.Suite
+}
+
+func TestBitSet(t *testing.T) {
+	suite.Run(t, new(bitSetTestSuite))
+}
+
+func (suite *bitSetTestSuite) TestInsertRemove() {
+	b := New(3, 64, 0, 200, 3)
+	suite.Require().Equal(4, b.Len())
+	suite.Require().Equal([]uint{0, 3, 64, 200}, slices.Collect(b.Seq()))
+	suite.Require().True(b.Contains(64))
+	suite.Require().False(b.Contains(63))
+	suite.Require().False(b.Contains(100000))
+
+	b.Remove(200, 1000)
+	suite.Require().Equal([]uint{0, 3, 64}, slices.Collect(b.Seq()))
+	suite.Require().Len(b.words, 2)
+	b.Remove(0, 3, 64)
+	suite.Require().True(b.IsEmpty())
+	suite.Require().Equal(0, b.Count())
+
+	b.Insert(5)
+	b.Clear()
+	suite.Require().True(b.IsEmpty())
+
+	for v := range New(1, 2, 3).Seq() {
+		suite.Require().Equal(uint(1), v)
+		break
+	}
+}
+
+func (suite *bitSetTestSuite) TestNextSet() {
+	b := New(5, 63, 64, 300)
+	var found []uint
+	for v, ok := b.NextSet(0); ok; v, ok = b.NextSet(v + 1) {
+		found = append(found, v)
+	}
+	suite.Require().Equal([]uint{5, 63, 64, 300}, found)
+	v, ok := b.NextSet(65)
+	suite.Require().True(ok)
+	suite.Require().Equal(uint(300), v)
+	_, ok = b.NextSet(301)
+	suite.Require().False(ok)
+	_, ok = New().NextSet(0)
+	suite.Require().False(ok)
+}
+
+func (suite *bitSetTestSuite) TestAlgebra() {
+	left := New(1, 2, 3, 100, 500)
+	right := New(2, 3, 4, 100)
+
+	suite.Require().Equal([]uint{1, 2, 3, 4, 100, 500}, slices.Collect(left.Union(right).Seq()))
+	suite.Require().Equal([]uint{2, 3, 100}, slices.Collect(left.Intersection(right).Seq()))
+	suite.Require().Equal([]uint{1, 500}, slices.Collect(left.Difference(right).Seq()))
+	suite.Require().Equal([]uint{4}, slices.Collect(right.Difference(left).Seq()))
+	suite.Require().Equal([]uint{1, 4, 500}, slices.Collect(left.SymmetricDifference(right).Seq()))
+	// operands are not changed
+	suite.Require().Equal(5, left.Len())
+	suite.Require().Equal(4, right.Len())
+
+	// results are trimmed, so Equal works
+	suite.Require().True(New(1).Equal(New(1, 500).Difference(New(500))))
+	suite.Require().True(New().Equal(New(500).Intersection(New(1))))
+	suite.Require().True(New().Equal(New(500).SymmetricDifference(New(500))))
+}
+
+func (suite *bitSetTestSuite) TestCompare() {
+	b := New(1, 70)
+	suite.Require().True(b.SubsetOf(New(1, 70, 200)))
+	suite.Require().True(b.SubsetOf(New(1, 70)))
+	suite.Require().False(b.SubsetOf(New(1)))
+	suite.Require().False(b.SubsetOf(New(1, 71)))
+	suite.Require().True(b.ProperSubsetOf(New(1, 70, 2)))
+	suite.Require().False(b.ProperSubsetOf(New(1, 70)))
+	suite.Require().True(New().SubsetOf(b))
+	suite.Require().True(b.IsDisjoint(New(2, 300)))
+	suite.Require().False(b.IsDisjoint(New(70)))
+
+	c := b.Clone()
+	suite.Require().True(c.Equal(b))
+	c.Insert(5)
+	suite.Require().False(c.Equal(b))
+	suite.Require().False(b.Contains(5))
+}
+
+func (suite *bitSetTestSuite) TestRandom() {
+	r := rand.New(rand.NewSource(1))
+	random := func() (*BitSet, map[uint]bool) {
+		b := New()
+		m := map[uint]bool{}
+		for range 200 {
+			v := uint(r.Intn(1000))
+			b.Insert(v)
+			m[v] = true
+		}
+		return b, m
+	}
+	check := func(b *BitSet, m map[uint]bool) {
+		suite.Require().Equal(slices.Sorted(maps.Keys(m)), slices.Collect(b.Seq()))
+		suite.Require().Equal(len(m), b.Count())
+	}
+
+	left, lm := random()
+	right, rm := random()
+	check(left, lm)
+
+	union, inter, diff := map[uint]bool{}, map[uint]bool{}, map[uint]bool{}
+	for v := range lm {
+		union[v] = true
+		if rm[v] {
+			inter[v] = true
+		} else {
+			diff[v] = true
+		}
+	}
+	for v := range rm {
+		union[v] = true
+	}
+	check(left.Union(right), union)
+	check(left.Intersection(right), inter)
+	check(left.Difference(right), diff)
+}
+
+func (suite *bitSetTestSuite) TestBinary() {
+	b := New(0, 63, 64, 1000)
+	data, err := b.MarshalBinary()
+	suite.Require().NoError(err)
+	suite.Require().Len(data, 16*8)
+
+	var res BitSet
+	suite.Require().NoError(res.UnmarshalBinary(data))
+	suite.Require().True(res.Equal(b))
+
+	// trailing empty words are dropped
+	suite.Require().NoError(res.UnmarshalBinary(append(data, make([]byte, 16)...)))
+	suite.Require().True(res.Equal(b))
+
+	suite.Require().ErrorIs(res.UnmarshalBinary([]byte{1, 2, 3}), ErrInvalidData)
+	suite.Require().True(res.Equal(b))
+
+	data, err = New().MarshalBinary()
+	suite.Require().NoError(err)
+	suite.Require().Empty(data)
+}
+
+func (suite *bitSetTestSuite) TestZeroValue() {
+	var b BitSet
+	suite.Require().False(b.Contains(1))
+	b.Remove(1)
+	suite.Require().Empty(slices.Collect(b.Seq()))
+	suite.Require().True(b.Equal(b.Clone()))
+	b.UnionWith(New(3))
+	suite.Require().Equal([]uint{3}, slices.Collect(b.Seq()))
+}
@@ -0,0 +1,235 @@
+// Package bloom provides probabilistic sets: Bloom filter and counting Bloom filter.
+//
+// Filters made by New and NewCounting hash values with hash/maphash, its seed is random for every process,
+// so their marshalled data can be loaded only by the same process.
+// Persistent filters require NewWithHash or NewCountingWithHash with a stable hash function and its id.
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/maphash"
+	"math"
+	"math/bits"
+)
+
+const (
+	wordBits = 64
+	// DefaultRate is used when the false positive rate is not in (0, 1).
+	DefaultRate = 0.01
+	version     = 1
+	headerLen   = 1 + 8 + 8 + 8
+)
+
+var (
+	ErrIncompatible = errors.New("filters have different sizes")
+	ErrInvalidData  = errors.New("invalid filter data")
+	ErrHashMismatch = errors.New("filter was built with another hash function")
+)
+
+var (
+	// seed is shared by all filters of the process, so they can be combined.
+	// hash/maphash doesn't allow fixed seeds, the seed is random for every process.
+	seed = maphash.MakeSeed()
+	// defaultHashID identifies the default hash. It depends on the seed, so it differs for every process
+	// and filters of different processes can't be combined or loaded by mistake.
+	defaultHashID = maphash.String(seed, "bloom")
+)
+
+func defaultHash[T comparable](value T) uint64 {
+	return maphash.Comparable(seed, value)
+}
+
+// size returns number of bits and hash functions for n elements and false positive rate p.
+func size(n int, p float64) (m, k int) {
+	if n < 1 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = DefaultRate
+	}
+	m = int(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k = int(math.Round(float64(m) / float64(n) * math.Ln2))
+	return max(m, 1), max(k, 1)
+}
+
+// locations calls f for k bit positions of the hash (double hashing).
+func locations(h uint64, m, k int, f func(pos int) bool) bool {
+	// the second hash is derived with splitmix64 finalizer and made odd so it is never 0
+	h2 := h
+	h2 ^= h2 >> 30
+	h2 *= 0xbf58476d1ce4e5b9
+	h2 ^= h2 >> 27
+	h2 *= 0x94d049bb133111eb
+	h2 ^= h2 >> 31
+	h2 |= 1
+	for i := 0; i < k; i++ {
+		if !f(int((h + uint64(i)*h2) % uint64(m))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter is a Bloom filter: a set which answers "maybe present" or "definitely absent"
+// using a fixed amount of memory.
+type Filter[T comparable] struct {
+	words  []uint64
+	m      int
+	k      int
+	hash   func(T) uint64
+	hashID uint64
+}
+
+// New creates a filter for n expected elements with false positive rate p.
+// Values are hashed with hash/maphash. Its seed is random for every process (maphash can't use fixed seeds),
+// so a marshalled filter can be loaded only by the same process, others get ErrHashMismatch.
+// Use NewWithHash with a stable hash for persistent filters.
+func New[T comparable](n int, p float64) *Filter[T] {
+	return NewWithHash(n, p, defaultHash[T], defaultHashID)
+}
+
+// NewWithHash creates a filter for n expected elements with false positive rate p
+// which uses the hash function. The id identifies the hash function: it is stored in the marshalled data
+// and compared by Union and UnmarshalBinary, so use different ids for different functions.
+// Filters are compatible only if they use the same hash.
+func NewWithHash[T comparable](n int, p float64, hash func(T) uint64, id uint64) *Filter[T] {
+	m, k := size(n, p)
+	return &Filter[T]{
+		words:  make([]uint64, (m+wordBits-1)/wordBits),
+		m:      m,
+		k:      k,
+		hash:   hash,
+		hashID: id,
+	}
+}
+
+// Bits returns the size of the filter in bits.
+func (f *Filter[T]) Bits() int {
+	return f.m
+}
+
+// Hashes returns the number of bits set for every value.
+func (f *Filter[T]) Hashes() int {
+	return f.k
+}
+
+// Add adds values to the filter.
+func (f *Filter[T]) Add(values ...T) {
+	for _, v := range values {
+		locations(f.hash(v), f.m, f.k, func(pos int) bool {
+			f.words[pos/wordBits] |= 1 << (pos % wordBits)
+			return true
+		})
+	}
+}
+
+// MayContain returns 'false' if the value was never added and 'true' if it probably was.
+func (f *Filter[T]) MayContain(value T) bool {
+	return locations(f.hash(value), f.m, f.k, func(pos int) bool {
+		return f.words[pos/wordBits]&(1<<(pos%wordBits)) != 0
+	})
+}
+
+// EstimatedCount returns approximate number of different added values.
+func (f *Filter[T]) EstimatedCount() int {
+	x := 0
+	for _, w := range f.words {
+		x += bits.OnesCount64(w)
+	}
+	if x >= f.m {
+		// the filter is full, the estimation is unbounded
+		return f.m
+	}
+	return int(math.Round(-float64(f.m) / float64(f.k) * math.Log(1-float64(x)/float64(f.m))))
+}
+
+// Union returns a new filter which may contain values of both filters.
+// Returns ErrIncompatible if filters have different sizes and ErrHashMismatch if they use different hashes.
+func (f *Filter[T]) Union(other *Filter[T]) (*Filter[T], error) {
+	if f.m != other.m || f.k != other.k {
+		return nil, ErrIncompatible
+	}
+	if f.hashID != other.hashID {
+		return nil, ErrHashMismatch
+	}
+	res := f.Clone()
+	for i, w := range other.words {
+		res.words[i] |= w
+	}
+	return res, nil
+}
+
+// Clone returns a new filter with same values.
+func (f *Filter[T]) Clone() *Filter[T] {
+	res := *f
+	res.words = append([]uint64(nil), f.words...)
+	return &res
+}
+
+// Clear removes all values.
+func (f *Filter[T]) Clear() {
+	clear(f.words)
+}
+
+// MarshalBinary encodes the filter: version, size in bits, number of hashes,
+// id of the hash function and the bits. The hash function itself is not encoded.
+func (f *Filter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, headerLen+len(f.words)*8)
+	data = encodeHeader(data, f.m, f.k, f.hashID)
+	for _, w := range f.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the filter by the result of MarshalBinary.
+// The hash function is kept, a zero value filter gets the default one.
+// Returns ErrHashMismatch if the data was made with another hash id
+// (or with the default hash by another process).
+func (f *Filter[T]) UnmarshalBinary(data []byte) error {
+	hash, id := f.hash, f.hashID
+	if hash == nil {
+		hash, id = defaultHash[T], defaultHashID
+	}
+	m, k, body, err := decodeHeader(data, id)
+	if err != nil {
+		return err
+	}
+	words := (m + wordBits - 1) / wordBits
+	if len(body) != words*8 {
+		return ErrInvalidData
+	}
+	f.words = make([]uint64, words)
+	for i := range f.words {
+		f.words[i] = binary.LittleEndian.Uint64(body[i*8:])
+	}
+	f.m = m
+	f.k = k
+	f.hash = hash
+	f.hashID = id
+	return nil
+}
+
+func encodeHeader(data []byte, m, k int, hashID uint64) []byte {
+	data = append(data, version)
+	data = binary.LittleEndian.AppendUint64(data, uint64(m))
+	data = binary.LittleEndian.AppendUint64(data, uint64(k))
+	return binary.LittleEndian.AppendUint64(data, hashID)
+}
+
+// decodeHeader checks the header and returns sizes and the rest of data.
+func decodeHeader(data []byte, hashID uint64) (m, k int, body []byte, err error) {
+	if len(data) < headerLen || data[0] != version {
+		return 0, 0, nil, ErrInvalidData
+	}
+	m64 := binary.LittleEndian.Uint64(data[1:])
+	k64 := binary.LittleEndian.Uint64(data[9:])
+	if m64 == 0 || k64 == 0 || m64 > math.MaxInt32*wordBits || k64 > math.MaxInt32 {
+		return 0, 0, nil, ErrInvalidData
+	}
+	if binary.LittleEndian.Uint64(data[17:]) != hashID {
+		return 0, 0, nil, ErrHashMismatch
+	}
+	return int(m64), int(k64), data[headerLen:], nil
+}
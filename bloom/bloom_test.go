@@ -0,0 +1,174 @@
+package bloom
+
+import (
+	"github.com/stretchr/testify/suite"
+	"hash/fnv"
+	"hash/maphash"
+	"testing"
+)
+
+type filterTestSuite struct {
+	suite.Suite
+}
+
+func TestFilter(t *testing.T) {
+	suite.Run(t, new(filterTestSuite))
+}
+
+// otherProcessHash works like the default hash of another process: same maphash, different seed and id.
+func otherProcessHash[T comparable]() (func(T) uint64, uint64) {
+	other := maphash.MakeSeed()
+	return func(v T) uint64 { return maphash.Comparable(other, v) }, maphash.String(other, "bloom")
+}
+
+// fnvID identifies fnvHash.
+const fnvID = 1
+
+func fnvHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func (suite *filterTestSuite) TestSize() {
+	f := New[int](1000, 0.01)
+	// about 9.6 bits and 7 hashes per element
+	suite.Require().Equal(9586, f.Bits())
+	suite.Require().Equal(7, f.Hashes())
+
+	f = New[int](0, 2)
+	suite.Require().Equal(New[int](1, DefaultRate).Bits(), f.Bits())
+	suite.Require().GreaterOrEqual(f.Hashes(), 1)
+}
+
+func (suite *filterTestSuite) TestFalsePositives() {
+	const n = 10000
+	f := New[int](n, 0.01)
+	for i := 0; i < n; i++ {
+		f.Add(i)
+	}
+	for i := 0; i < n; i++ {
+		suite.Require().True(f.MayContain(i))
+	}
+	positives := 0
+	for i := n; i < 2*n; i++ {
+		if f.MayContain(i) {
+			positives++
+		}
+	}
+	suite.Require().Less(positives, n*2/100, "false positive rate is too big")
+
+	estimated := f.EstimatedCount()
+	suite.Require().InDelta(n, estimated, n*0.05)
+
+	f.Clear()
+	suite.Require().False(f.MayContain(1))
+	suite.Require().Equal(0, f.EstimatedCount())
+}
+
+func (suite *filterTestSuite) TestUnion() {
+	left := New[string](100, 0.01)
+	right := New[string](100, 0.01)
+	left.Add("a", "b")
+	right.Add("c")
+
+	union, err := left.Union(right)
+	suite.Require().NoError(err)
+	for _, v := range []string{"a", "b", "c"} {
+		suite.Require().True(union.MayContain(v))
+	}
+	suite.Require().False(left.MayContain("c"))
+	suite.Require().Equal(3, union.EstimatedCount())
+
+	_, err = left.Union(New[string](1000, 0.01))
+	suite.Require().ErrorIs(err, ErrIncompatible)
+}
+
+func (suite *filterTestSuite) TestFull() {
+	f := New[int](1, 0.5)
+	for i := 0; i < 1000; i++ {
+		f.Add(i)
+	}
+	suite.Require().Equal(f.Bits(), f.EstimatedCount())
+}
+
+func (suite *filterTestSuite) TestBinary() {
+	f := NewWithHash(100, 0.01, fnvHash, fnvID)
+	f.Add("a", "b")
+	data, err := f.MarshalBinary()
+	suite.Require().NoError(err)
+
+	// a filter with the same stable hash can be loaded anywhere
+	loaded := NewWithHash(1, 0.5, fnvHash, fnvID)
+	suite.Require().NoError(loaded.UnmarshalBinary(data))
+	suite.Require().Equal(f.Bits(), loaded.Bits())
+	suite.Require().Equal(f.Hashes(), loaded.Hashes())
+	suite.Require().True(loaded.MayContain("a"))
+	suite.Require().True(loaded.MayContain("b"))
+	suite.Require().False(loaded.MayContain("c"))
+
+	// zero value gets the default hash
+	def := New[string](100, 0.01)
+	def.Add("x")
+	data, err = def.MarshalBinary()
+	suite.Require().NoError(err)
+	var zero Filter[string]
+	suite.Require().NoError(zero.UnmarshalBinary(data))
+	suite.Require().True(zero.MayContain("x"))
+
+	suite.Require().ErrorIs(zero.UnmarshalBinary(nil), ErrInvalidData)
+	suite.Require().ErrorIs(zero.UnmarshalBinary(data[:len(data)-1]), ErrInvalidData)
+	broken := append([]byte{}, data...)
+	broken[0] = 2
+	suite.Require().ErrorIs(zero.UnmarshalBinary(broken), ErrInvalidData)
+	suite.Require().True(zero.MayContain("x"))
+}
+
+func (suite *filterTestSuite) TestHashMismatch() {
+	f := New[string](100, 0.01)
+	f.Add("a")
+	data, err := f.MarshalBinary()
+	suite.Require().NoError(err)
+
+	hash, id := otherProcessHash[string]()
+	other := NewWithHash(100, 0.01, hash, id)
+	other.Add("b")
+	suite.Require().ErrorIs(other.UnmarshalBinary(data), ErrHashMismatch)
+	// the filter is not changed
+	suite.Require().True(other.MayContain("b"))
+
+	suite.Require().ErrorIs(NewWithHash(100, 0.01, fnvHash, fnvID).UnmarshalBinary(data), ErrHashMismatch)
+
+	data, err = NewWithHash(100, 0.01, fnvHash, fnvID).MarshalBinary()
+	suite.Require().NoError(err)
+	var zero Filter[string]
+	suite.Require().ErrorIs(zero.UnmarshalBinary(data), ErrHashMismatch)
+
+	_, err = f.Union(other)
+	suite.Require().ErrorIs(err, ErrHashMismatch)
+}
+
+func (suite *filterTestSuite) TestPointerHash() {
+	type item struct{ name string }
+	// the hash dereferences the pointer, so it must never be called with nil
+	hash := func(v *item) uint64 { return fnvHash(v.name) }
+	f := NewWithHash(100, 0.01, hash, fnvID)
+	f.Add(&item{"a"})
+	suite.Require().True(f.MayContain(&item{"a"}))
+
+	data, err := f.MarshalBinary()
+	suite.Require().NoError(err)
+	loaded := NewWithHash(1, 0.5, hash, fnvID)
+	suite.Require().NoError(loaded.UnmarshalBinary(data))
+	suite.Require().True(loaded.MayContain(&item{"a"}))
+
+	union, err := f.Union(loaded)
+	suite.Require().NoError(err)
+	suite.Require().True(union.MayContain(&item{"a"}))
+
+	c := NewCountingWithHash(100, 0.01, hash, fnvID)
+	c.Add(&item{"a"})
+	data, err = c.MarshalBinary()
+	suite.Require().NoError(err)
+	suite.Require().NoError(NewCountingWithHash(1, 0.5, hash, fnvID).UnmarshalBinary(data))
+}
@@ -0,0 +1,101 @@
+package bloom
+
+import (
+	"math"
+)
+
+// CountingFilter is a Bloom filter with a counter instead of a bit, so values can be removed.
+// Counters are 8-bit, a counter which reached 255 is never decremented (to keep "definitely absent" correct).
+type CountingFilter[T comparable] struct {
+	counters []uint8
+	k        int
+	hash     func(T) uint64
+	hashID   uint64
+}
+
+// NewCounting creates a counting filter for n expected elements with false positive rate p.
+// Hashing is the same as in New: a marshalled filter can be loaded only by the same process.
+func NewCounting[T comparable](n int, p float64) *CountingFilter[T] {
+	return NewCountingWithHash(n, p, defaultHash[T], defaultHashID)
+}
+
+// NewCountingWithHash creates a counting filter which uses the hash function identified by the id (see NewWithHash).
+func NewCountingWithHash[T comparable](n int, p float64, hash func(T) uint64, id uint64) *CountingFilter[T] {
+	m, k := size(n, p)
+	return &CountingFilter[T]{
+		counters: make([]uint8, m),
+		k:        k,
+		hash:     hash,
+		hashID:   id,
+	}
+}
+
+// Add adds values to the filter.
+func (f *CountingFilter[T]) Add(values ...T) {
+	for _, v := range values {
+		locations(f.hash(v), len(f.counters), f.k, func(pos int) bool {
+			if f.counters[pos] < math.MaxUint8 {
+				f.counters[pos]++
+			}
+			return true
+		})
+	}
+}
+
+// Remove removes one copy of the value. Returns 'false' and does nothing if the value is definitely absent.
+// Removing a value which was never added can make the filter forget other values.
+func (f *CountingFilter[T]) Remove(value T) bool {
+	if !f.MayContain(value) {
+		return false
+	}
+	locations(f.hash(value), len(f.counters), f.k, func(pos int) bool {
+		if f.counters[pos] < math.MaxUint8 {
+			f.counters[pos]--
+		}
+		return true
+	})
+	return true
+}
+
+// MayContain returns 'false' if the value is not in the filter and 'true' if it probably is.
+func (f *CountingFilter[T]) MayContain(value T) bool {
+	return locations(f.hash(value), len(f.counters), f.k, func(pos int) bool {
+		return f.counters[pos] != 0
+	})
+}
+
+// Clear removes all values.
+func (f *CountingFilter[T]) Clear() {
+	clear(f.counters)
+}
+
+// MarshalBinary encodes the filter: version, number of counters, number of hashes,
+// id of the hash function and the counters. The hash function itself is not encoded.
+func (f *CountingFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, headerLen+len(f.counters))
+	data = encodeHeader(data, len(f.counters), f.k, f.hashID)
+	return append(data, f.counters...), nil
+}
+
+// UnmarshalBinary replaces the filter by the result of MarshalBinary.
+// The hash function is kept, a zero value filter gets the default one.
+// Returns ErrHashMismatch if the data was made with another hash id
+// (or with the default hash by another process).
+func (f *CountingFilter[T]) UnmarshalBinary(data []byte) error {
+	hash, id := f.hash, f.hashID
+	if hash == nil {
+		hash, id = defaultHash[T], defaultHashID
+	}
+	m, k, body, err := decodeHeader(data, id)
+	if err != nil {
+		return err
+	}
+	if len(body) != m {
+		return ErrInvalidData
+	}
+	f.counters = append([]uint8(nil), body...)
+	f.k = k
+	f.hash = hash
+	f.hashID = id
+	return nil
+}
@@ -0,0 +1,97 @@
+package bloom
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type countingFilterTestSuite struct {
+	suite.Suite
+}
+
+func TestCountingFilter(t *testing.T) {
+	suite.Run(t, new(countingFilterTestSuite))
+}
+
+func (suite *countingFilterTestSuite) TestAddRemove() {
+	f := NewCounting[string](100, 0.01)
+	suite.Require().False(f.MayContain("a"))
+	suite.Require().False(f.Remove("a"))
+
+	f.Add("a", "b", "a")
+	suite.Require().True(f.MayContain("a"))
+	suite.Require().True(f.MayContain("b"))
+
+	suite.Require().True(f.Remove("a"))
+	suite.Require().True(f.MayContain("a"))
+	suite.Require().True(f.Remove("a"))
+	suite.Require().False(f.MayContain("a"))
+	suite.Require().True(f.MayContain("b"))
+
+	f.Clear()
+	suite.Require().False(f.MayContain("b"))
+}
+
+func (suite *countingFilterTestSuite) TestMany() {
+	const n = 5000
+	f := NewCounting[int](n, 0.01)
+	for i := 0; i < n; i++ {
+		f.Add(i)
+	}
+	for i := 0; i < n; i += 2 {
+		suite.Require().True(f.Remove(i))
+	}
+	for i := 1; i < n; i += 2 {
+		suite.Require().True(f.MayContain(i))
+	}
+	positives := 0
+	for i := 0; i < n; i += 2 {
+		if f.MayContain(i) {
+			positives++
+		}
+	}
+	suite.Require().Less(positives, n/100)
+}
+
+func (suite *countingFilterTestSuite) TestSaturation() {
+	f := NewCounting[int](10, 0.01)
+	for i := 0; i < 300; i++ {
+		f.Add(1)
+	}
+	for i := 0; i < 300; i++ {
+		f.Remove(1)
+	}
+	// saturated counters are kept, the value is not lost
+	suite.Require().True(f.MayContain(1))
+}
+
+func (suite *countingFilterTestSuite) TestBinary() {
+	f := NewCountingWithHash(100, 0.01, fnvHash, fnvID)
+	f.Add("a", "a", "b")
+	data, err := f.MarshalBinary()
+	suite.Require().NoError(err)
+
+	var loaded CountingFilter[string]
+	loaded.hash, loaded.hashID = fnvHash, fnvID
+	suite.Require().NoError(loaded.UnmarshalBinary(data))
+	suite.Require().True(loaded.Remove("a"))
+	suite.Require().True(loaded.MayContain("a"))
+	suite.Require().True(loaded.MayContain("b"))
+	suite.Require().False(loaded.MayContain("c"))
+
+	suite.Require().ErrorIs(loaded.UnmarshalBinary(data[:len(data)-1]), ErrInvalidData)
+}
+
+func (suite *countingFilterTestSuite) TestHashMismatch() {
+	f := NewCounting[string](100, 0.01)
+	f.Add("a")
+	data, err := f.MarshalBinary()
+	suite.Require().NoError(err)
+
+	hash, id := otherProcessHash[string]()
+	other := NewCountingWithHash(100, 0.01, hash, id)
+	suite.Require().ErrorIs(other.UnmarshalBinary(data), ErrHashMismatch)
+	var zero CountingFilter[string]
+	suite.Require().NoError(zero.UnmarshalBinary(data))
+	suite.Require().True(zero.MayContain("a"))
+}
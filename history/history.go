@@ -0,0 +1,285 @@
+// Package history provides undo/redo manager built on stack
+package history
+
+import (
+	"errors"
+
+	"github.com/HoskeOwl/ggstruct/stack"
+)
+
+var (
+	ErrNothingToUndo     = errors.New("nothing to undo")
+	ErrNothingToRedo     = errors.New("nothing to redo")
+	ErrInTransaction     = errors.New("transaction is not finished")
+	ErrNoTransaction     = errors.New("no transaction")
+	ErrUnknownCheckpoint = errors.New("checkpoint is not reachable")
+)
+
+// Command is an action which can be undone.
+type Command interface {
+	Do() error
+	Undo() error
+}
+
+type funcCommand struct {
+	do   func() error
+	undo func() error
+}
+
+func (c funcCommand) Do() error   { return c.do() }
+func (c funcCommand) Undo() error { return c.undo() }
+
+// NewCommand returns Command from two functions.
+func NewCommand(do, undo func() error) Command {
+	return funcCommand{do: do, undo: undo}
+}
+
+// entry is one undoable step: a single command or a transaction.
+type entry struct {
+	commands []Command
+	seq      uint64
+}
+
+// undo undoes commands from the last one. If a command fails the already undone commands are done again,
+// so the entry stays applied and can be undone later.
+func (e *entry) undo() error {
+	for i := len(e.commands) - 1; i >= 0; i-- {
+		if err := e.commands[i].Undo(); err != nil {
+			for _, c := range e.commands[i+1:] {
+				if rerr := c.Do(); rerr != nil {
+					return errors.Join(err, rerr)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// redo does commands from the first one. If a command fails the already done commands are undone again,
+// so the entry stays undone and can be redone later.
+func (e *entry) redo() error {
+	for i, c := range e.commands {
+		if err := c.Do(); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				if rerr := e.commands[j].Undo(); rerr != nil {
+					return errors.Join(err, rerr)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// History keeps done commands to undo them and undone commands to redo them.
+// The zero value is an empty history without depth limit ready to use.
+type History struct {
+	undo  stack.Stack[*entry]
+	redo  stack.Stack[*entry]
+	depth int
+	// sequence number of the last saved entry
+	seq uint64
+	// sequence number of the last entry dropped because of the depth, 0 is the empty history
+	floor uint64
+	// current transaction, nil if there is no one
+	tx      *entry
+	txLevel int
+	// sequence number of the top entry at the moment of the checkpoint
+	checkpoints map[string]uint64
+}
+
+// New returns a new history which keeps up to 'depth' undoable steps, the oldest steps are dropped.
+// depth=0 mens no limits.
+func New(depth int) *History {
+	h := &History{depth: depth}
+	h.undo.WithLimit(depth)
+	return h
+}
+
+// Depth returns the maximum number of undoable steps.
+func (h *History) Depth() int {
+	return h.depth
+}
+
+// Do executes the command and saves it. The redo history is cleared.
+// If the command returns an error nothing is saved.
+func (h *History) Do(cmd Command) error {
+	if err := cmd.Do(); err != nil {
+		return err
+	}
+	if h.tx != nil {
+		h.tx.commands = append(h.tx.commands, cmd)
+		return nil
+	}
+	h.push(&entry{commands: []Command{cmd}})
+	return nil
+}
+
+func (h *History) push(e *entry) {
+	h.seq++
+	e.seq = h.seq
+	if bottom, evicted := h.undo.PushEvict(e); evicted {
+		h.floor = bottom.seq
+	}
+	h.redo.Clear()
+}
+
+// Undo reverts the last step. If a command returns an error the already undone commands of the step
+// are done again and the history is not changed. If that fails too both errors are returned
+// and the state is unknown.
+func (h *History) Undo() error {
+	if h.tx != nil {
+		return ErrInTransaction
+	}
+	e, exists := h.undo.Top()
+	if !exists {
+		return ErrNothingToUndo
+	}
+	if err := e.undo(); err != nil {
+		return err
+	}
+	h.undo.Pop()
+	h.redo.Push(e)
+	return nil
+}
+
+// Redo executes again the last undone step. If a command returns an error the already done commands of the step
+// are undone again and the history is not changed. If that fails too both errors are returned
+// and the state is unknown.
+func (h *History) Redo() error {
+	if h.tx != nil {
+		return ErrInTransaction
+	}
+	e, exists := h.redo.Top()
+	if !exists {
+		return ErrNothingToRedo
+	}
+	if err := e.redo(); err != nil {
+		return err
+	}
+	h.redo.Pop()
+	h.undo.Push(e)
+	return nil
+}
+
+// CanUndo returns 'true' if there is a step to undo.
+func (h *History) CanUndo() bool {
+	return h.tx == nil && !h.undo.IsEmpty()
+}
+
+// CanRedo returns 'true' if there is a step to redo.
+func (h *History) CanRedo() bool {
+	return h.tx == nil && !h.redo.IsEmpty()
+}
+
+// UndoLen returns the number of steps to undo.
+func (h *History) UndoLen() int {
+	return h.undo.Len()
+}
+
+// RedoLen returns the number of steps to redo.
+func (h *History) RedoLen() int {
+	return h.redo.Len()
+}
+
+// Begin starts a transaction: all commands until Commit are undone and redone as one step.
+// Nested Begin calls join the outer transaction.
+func (h *History) Begin() {
+	if h.tx == nil {
+		h.tx = &entry{}
+	}
+	h.txLevel++
+}
+
+// Commit finishes the transaction. The outermost Commit saves the commands as one step.
+func (h *History) Commit() error {
+	if h.tx == nil {
+		return ErrNoTransaction
+	}
+	h.txLevel--
+	if h.txLevel > 0 {
+		return nil
+	}
+	tx := h.tx
+	h.tx = nil
+	if len(tx.commands) > 0 {
+		h.push(tx)
+	}
+	return nil
+}
+
+// Rollback undoes all commands of the transaction and discards it (including outer levels).
+// If a command returns an error the already undone commands are done again and the transaction is kept.
+func (h *History) Rollback() error {
+	if h.tx == nil {
+		return ErrNoTransaction
+	}
+	if err := h.tx.undo(); err != nil {
+		return err
+	}
+	h.tx = nil
+	h.txLevel = 0
+	return nil
+}
+
+// Checkpoint saves the current state with the name. Existing checkpoint with the same name is replaced.
+func (h *History) Checkpoint(name string) {
+	if h.checkpoints == nil {
+		h.checkpoints = make(map[string]uint64)
+	}
+	h.checkpoints[name] = h.topSeq()
+}
+
+func (h *History) topSeq() uint64 {
+	if e, exists := h.undo.Top(); exists {
+		return e.seq
+	}
+	return h.floor
+}
+
+// reachable checks is the state after the entry with the sequence number can be restored by Undo.
+func (h *History) reachable(seq uint64) bool {
+	if seq == h.floor {
+		return true
+	}
+	for e := range h.undo.Seq() {
+		if e.seq == seq {
+			return true
+		}
+	}
+	return false
+}
+
+// UndoTo undoes steps until the state of the checkpoint.
+// Returns ErrUnknownCheckpoint if there is no such checkpoint or its state was undone or dropped.
+func (h *History) UndoTo(name string) error {
+	if h.tx != nil {
+		return ErrInTransaction
+	}
+	target, exists := h.checkpoints[name]
+	if !exists || !h.reachable(target) {
+		return ErrUnknownCheckpoint
+	}
+	for h.topSeq() != target {
+		if err := h.Undo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveCheckpoint removes the checkpoint.
+func (h *History) RemoveCheckpoint(name string) {
+	delete(h.checkpoints, name)
+}
+
+// Clear removes all steps, checkpoints and the current transaction.
+func (h *History) Clear() {
+	h.undo.Clear()
+	h.redo.Clear()
+	h.floor = h.seq
+	h.tx = nil
+	h.txLevel = 0
+	h.checkpoints = nil
+}
@@ -0,0 +1,295 @@
+package history
+
+import (
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type HistoryTestSuite struct {
+	suite.Suite
+	value int
+}
+
+func TestRunHistorySuite(t *testing.T) {
+	suite.Run(t, new(HistoryTestSuite))
+}
+
+func (s *HistoryTestSuite) SetupTest() {
+	s.value = 0
+}
+
+func (s *HistoryTestSuite) add(n int) Command {
+	return NewCommand(
+		func() error { s.value += n; return nil },
+		func() error { s.value -= n; return nil },
+	)
+}
+
+func (s *HistoryTestSuite) TestUndoRedo() {
+	h := New(0)
+	s.Require().False(h.CanUndo())
+	s.Require().False(h.CanRedo())
+	s.Require().ErrorIs(h.Undo(), ErrNothingToUndo)
+	s.Require().ErrorIs(h.Redo(), ErrNothingToRedo)
+
+	s.Require().NoError(h.Do(s.add(1)))
+	s.Require().NoError(h.Do(s.add(10)))
+	s.Require().Equal(11, s.value)
+	s.Require().True(h.CanUndo())
+	s.Require().Equal(2, h.UndoLen())
+
+	s.Require().NoError(h.Undo())
+	s.Require().Equal(1, s.value)
+	s.Require().True(h.CanRedo())
+	s.Require().NoError(h.Undo())
+	s.Require().Equal(0, s.value)
+	s.Require().Equal(2, h.RedoLen())
+
+	s.Require().NoError(h.Redo())
+	s.Require().Equal(1, s.value)
+
+	// a new command clears redo history
+	s.Require().NoError(h.Do(s.add(100)))
+	s.Require().Equal(101, s.value)
+	s.Require().False(h.CanRedo())
+}
+
+func (s *HistoryTestSuite) TestErrors() {
+	h := New(0)
+	fail := errors.New("fail")
+
+	s.Require().ErrorIs(h.Do(NewCommand(func() error { return fail }, nil)), fail)
+	s.Require().False(h.CanUndo())
+
+	undoFails := true
+	h.Do(NewCommand(
+		func() error { s.value++; return nil },
+		func() error {
+			if undoFails {
+				return fail
+			}
+			s.value--
+			return nil
+		},
+	))
+	s.Require().ErrorIs(h.Undo(), fail)
+	s.Require().Equal(1, h.UndoLen())
+	undoFails = false
+	s.Require().NoError(h.Undo())
+	s.Require().Equal(0, s.value)
+}
+
+func (s *HistoryTestSuite) TestTransactionErrors() {
+	h := New(0)
+	fail := errors.New("fail")
+	fails := 0
+	// fails once on every call when fails > 0
+	flaky := func(n int) Command {
+		return NewCommand(
+			func() error {
+				if fails > 0 {
+					fails--
+					return fail
+				}
+				s.value += n
+				return nil
+			},
+			func() error {
+				if fails > 0 {
+					fails--
+					return fail
+				}
+				s.value -= n
+				return nil
+			},
+		)
+	}
+
+	h.Begin()
+	h.Do(flaky(1))
+	h.Do(s.add(10))
+	s.Require().NoError(h.Commit())
+	s.Require().Equal(11, s.value)
+
+	// the first command fails, the second one is done again
+	fails = 1
+	s.Require().ErrorIs(h.Undo(), fail)
+	s.Require().Equal(11, s.value)
+	s.Require().Equal(1, h.UndoLen())
+	s.Require().NoError(h.Undo())
+	s.Require().Equal(0, s.value)
+
+	h.Begin()
+	h.Do(s.add(10))
+	h.Do(flaky(1))
+	s.Require().NoError(h.Commit())
+	s.Require().NoError(h.Undo())
+	s.Require().Equal(0, s.value)
+
+	// the second command fails, the first one is undone again
+	fails = 1
+	s.Require().ErrorIs(h.Redo(), fail)
+	s.Require().Equal(0, s.value)
+	s.Require().Equal(1, h.RedoLen())
+	s.Require().NoError(h.Redo())
+	s.Require().Equal(11, s.value)
+
+	// restoring fails too: both errors are returned
+	h.Begin()
+	h.Do(NewCommand(func() error { return nil }, func() error { return fail }))
+	restoreFail := errors.New("restore")
+	var doErr error
+	h.Do(NewCommand(func() error { return doErr }, func() error { return nil }))
+	s.Require().NoError(h.Commit())
+	doErr = restoreFail
+	err := h.Undo()
+	s.Require().ErrorIs(err, fail)
+	s.Require().ErrorIs(err, restoreFail)
+	h.Clear()
+
+	// failed rollback keeps the transaction
+	before := s.value
+	h.Begin()
+	h.Do(flaky(1))
+	h.Do(s.add(10))
+	fails = 1
+	s.Require().ErrorIs(h.Rollback(), fail)
+	s.Require().Equal(before+11, s.value)
+	s.Require().NoError(h.Rollback())
+	s.Require().Equal(before, s.value)
+	s.Require().ErrorIs(h.Rollback(), ErrNoTransaction)
+}
+
+func (s *HistoryTestSuite) TestDepth() {
+	h := New(2)
+	s.Require().Equal(2, h.Depth())
+	h.Do(s.add(1))
+	h.Do(s.add(10))
+	h.Do(s.add(100))
+	s.Require().Equal(2, h.UndoLen())
+
+	s.Require().NoError(h.Undo())
+	s.Require().NoError(h.Undo())
+	s.Require().ErrorIs(h.Undo(), ErrNothingToUndo)
+	// the oldest step is dropped
+	s.Require().Equal(1, s.value)
+}
+
+func (s *HistoryTestSuite) TestTransaction() {
+	h := New(0)
+	h.Do(s.add(1))
+
+	h.Begin()
+	h.Do(s.add(10))
+	h.Begin()
+	h.Do(s.add(100))
+	s.Require().NoError(h.Commit())
+	s.Require().False(h.CanUndo())
+	s.Require().ErrorIs(h.Undo(), ErrInTransaction)
+	h.Do(s.add(1000))
+	s.Require().NoError(h.Commit())
+	s.Require().ErrorIs(h.Commit(), ErrNoTransaction)
+
+	s.Require().Equal(1111, s.value)
+	s.Require().Equal(2, h.UndoLen())
+	s.Require().NoError(h.Undo())
+	s.Require().Equal(1, s.value)
+	s.Require().NoError(h.Redo())
+	s.Require().Equal(1111, s.value)
+
+	// empty transaction is not saved
+	h.Begin()
+	s.Require().NoError(h.Commit())
+	s.Require().Equal(2, h.UndoLen())
+}
+
+func (s *HistoryTestSuite) TestRollback() {
+	h := New(0)
+	s.Require().ErrorIs(h.Rollback(), ErrNoTransaction)
+	h.Do(s.add(1))
+	h.Begin()
+	h.Do(s.add(10))
+	h.Begin()
+	h.Do(s.add(100))
+	s.Require().NoError(h.Rollback())
+	s.Require().Equal(1, s.value)
+	s.Require().Equal(1, h.UndoLen())
+	s.Require().True(h.CanUndo())
+}
+
+func (s *HistoryTestSuite) TestCheckpoint() {
+	h := New(0)
+	h.Checkpoint("start")
+	h.Do(s.add(1))
+	h.Do(s.add(10))
+	h.Checkpoint("middle")
+	h.Do(s.add(100))
+	h.Do(s.add(1000))
+
+	s.Require().ErrorIs(h.UndoTo("unknown"), ErrUnknownCheckpoint)
+	s.Require().NoError(h.UndoTo("middle"))
+	s.Require().Equal(11, s.value)
+	s.Require().Equal(2, h.RedoLen())
+	s.Require().NoError(h.UndoTo("middle"))
+	s.Require().Equal(11, s.value)
+
+	s.Require().NoError(h.UndoTo("start"))
+	s.Require().Equal(0, s.value)
+	// the state of "middle" was undone
+	s.Require().ErrorIs(h.UndoTo("middle"), ErrUnknownCheckpoint)
+
+	h.RemoveCheckpoint("start")
+	s.Require().ErrorIs(h.UndoTo("start"), ErrUnknownCheckpoint)
+}
+
+func (s *HistoryTestSuite) TestCheckpointEvicted() {
+	h := New(1)
+	h.Checkpoint("start")
+	h.Do(s.add(1))
+	h.Checkpoint("one")
+	h.Do(s.add(10))
+	s.Require().ErrorIs(h.UndoTo("start"), ErrUnknownCheckpoint)
+	s.Require().NoError(h.UndoTo("one"))
+	s.Require().Equal(1, s.value)
+}
+
+func (s *HistoryTestSuite) TestClear() {
+	h := New(0)
+	h.Do(s.add(1))
+	h.Do(s.add(1))
+	h.Undo()
+	h.Checkpoint("x")
+	h.Begin()
+	h.Clear()
+	s.Require().False(h.CanUndo())
+	s.Require().False(h.CanRedo())
+	s.Require().ErrorIs(h.Commit(), ErrNoTransaction)
+	s.Require().ErrorIs(h.UndoTo("x"), ErrUnknownCheckpoint)
+}
+
+func (s *HistoryTestSuite) TestZeroValue() {
+	var h History
+	s.Require().Equal(0, h.Depth())
+	s.Require().False(h.CanUndo())
+	s.Require().False(h.CanRedo())
+	s.Require().Equal(0, h.UndoLen())
+	s.Require().Equal(0, h.RedoLen())
+	s.Require().ErrorIs(h.Undo(), ErrNothingToUndo)
+	s.Require().ErrorIs(h.Redo(), ErrNothingToRedo)
+	s.Require().ErrorIs(h.UndoTo("x"), ErrUnknownCheckpoint)
+	s.Require().ErrorIs(h.Commit(), ErrNoTransaction)
+	s.Require().ErrorIs(h.Rollback(), ErrNoTransaction)
+	h.RemoveCheckpoint("x")
+	h.Clear()
+
+	h.Checkpoint("start")
+	s.Require().NoError(h.Do(s.add(1)))
+	h.Begin()
+	h.Do(s.add(10))
+	s.Require().NoError(h.Commit())
+	s.Require().NoError(h.UndoTo("start"))
+	s.Require().Equal(0, s.value)
+	s.Require().NoError(h.Redo())
+	s.Require().Equal(1, s.value)
+}
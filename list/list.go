@@ -319,6 +319,7 @@ func (l *List[T]) PopFront() (val T, exists bool) {
 	r := l.root
 	next := r.next
 	next.prev = r.prev
+	r.prev.next = next
 	if l.len == 0 {
 		l.root = nil
 	} else {
@@ -347,6 +348,7 @@ func (l *List[T]) PopBack() (val T, exists bool) {
 		l.root = nil
 	} else {
 		l.root.prev = prev
+		prev.next = l.root
 	}
 	p.clear()
 
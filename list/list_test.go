@@ -555,3 +555,23 @@ func (l *ListTestSuite) TestPopAt() {
 	res = slices.Collect(lst.Seq())
 	l.Require().Equal(exp, res)
 }
+
+func (l *ListTestSuite) TestPopKeepsLinks() {
+	lst := New(0, 1, 2, 3)
+	lst.PopFront()
+	v, exists := lst.PopAt(2)
+	l.Require().True(exists)
+	l.Require().Equal(3, v)
+	lst.PushBack(4)
+	l.Require().Equal([]int{1, 2, 4}, slices.Collect(lst.Seq()))
+	l.Require().Equal([]int{4, 2, 1}, slices.Collect(lst.ReversedSeq()))
+
+	lst = New(0, 1, 2, 3)
+	lst.PopBack()
+	v, exists = lst.PopAt(2)
+	l.Require().True(exists)
+	l.Require().Equal(2, v)
+	lst.PushFront(5)
+	l.Require().Equal([]int{5, 0, 1}, slices.Collect(lst.Seq()))
+	l.Require().Equal([]int{1, 0, 5}, slices.Collect(lst.ReversedSeq()))
+}
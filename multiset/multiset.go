@@ -0,0 +1,216 @@
+// Package multiset provides a multiset (bag): a set which counts occurrences of elements
+package multiset
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/HoskeOwl/ggstruct/set"
+)
+
+// Entry is an element with its count.
+type Entry[T comparable] struct {
+	Value T
+	Count int
+}
+
+// Multiset is an unordered collection which keeps a count for every element.
+// The zero value is an empty multiset ready to use.
+type Multiset[T comparable] struct {
+	counts map[T]int
+	len    int
+}
+
+// New creates a new multiset. Every initial value is added once, repeated values are counted.
+func New[T comparable](initial ...T) *Multiset[T] {
+	m := &Multiset[T]{counts: make(map[T]int)}
+	for _, v := range initial {
+		m.Add(v, 1)
+	}
+	return m
+}
+
+// Add adds n copies of the value. n <= 0 does nothing.
+func (m *Multiset[T]) Add(value T, n int) {
+	if n <= 0 {
+		return
+	}
+	if m.counts == nil {
+		m.counts = make(map[T]int)
+	}
+	m.counts[value] += n
+	m.len += n
+}
+
+// Remove removes up to n copies of the value and returns how many were removed.
+func (m *Multiset[T]) Remove(value T, n int) int {
+	count := m.counts[value]
+	if n <= 0 || count == 0 {
+		return 0
+	}
+	if n >= count {
+		n = count
+		delete(m.counts, value)
+	} else {
+		m.counts[value] = count - n
+	}
+	m.len -= n
+	return n
+}
+
+// RemoveAll removes all copies of the value and returns how many were removed.
+func (m *Multiset[T]) RemoveAll(value T) int {
+	return m.Remove(value, m.counts[value])
+}
+
+// Count returns the number of copies of the value.
+func (m *Multiset[T]) Count(value T) int {
+	return m.counts[value]
+}
+
+// Contains checks is at least one copy of the value in the multiset.
+func (m *Multiset[T]) Contains(value T) bool {
+	_, exists := m.counts[value]
+	return exists
+}
+
+// Len returns the total number of elements counting all copies.
+func (m *Multiset[T]) Len() int {
+	return m.len
+}
+
+// DistinctLen returns the number of different elements.
+func (m *Multiset[T]) DistinctLen() int {
+	return len(m.counts)
+}
+
+// IsEmpty returns 'true' if there are no elements.
+func (m *Multiset[T]) IsEmpty() bool {
+	return m.len == 0
+}
+
+// Distinct returns different elements as a set.
+func (m *Multiset[T]) Distinct() *set.Set[T] {
+	s := set.New[T]()
+	for v := range m.counts {
+		s.Insert(v)
+	}
+	return s
+}
+
+// MostCommon returns up to k elements with the biggest counts, most common first.
+// k <= 0 returns all elements. Order of elements with equal counts is not defined.
+func (m *Multiset[T]) MostCommon(k int) []Entry[T] {
+	entries := make([]Entry[T], 0, len(m.counts))
+	for v, c := range m.counts {
+		entries = append(entries, Entry[T]{Value: v, Count: c})
+	}
+	slices.SortFunc(entries, func(a, b Entry[T]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	if k > 0 && k < len(entries) {
+		entries = entries[:k]
+	}
+	return entries
+}
+
+// Seq returns value-iterator. Every value is yielded as many times as it is counted.
+func (m *Multiset[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, c := range m.counts {
+			for range c {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Seq2 returns iterator over different values and their counts.
+func (m *Multiset[T]) Seq2() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for v, c := range m.counts {
+			if !yield(v, c) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new multiset where every count is the maximum of both counts.
+func (m *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	res := m.Clone()
+	for v, c := range other.counts {
+		if c > res.counts[v] {
+			res.Add(v, c-res.counts[v])
+		}
+	}
+	return res
+}
+
+// Sum returns a new multiset where every count is the sum of both counts.
+func (m *Multiset[T]) Sum(other *Multiset[T]) *Multiset[T] {
+	res := m.Clone()
+	for v, c := range other.counts {
+		res.Add(v, c)
+	}
+	return res
+}
+
+// Intersection returns a new multiset where every count is the minimum of both counts.
+func (m *Multiset[T]) Intersection(other *Multiset[T]) *Multiset[T] {
+	small, big := m, other
+	if small.DistinctLen() > big.DistinctLen() {
+		small, big = big, small
+	}
+	res := New[T]()
+	for v, c := range small.counts {
+		res.Add(v, min(c, big.counts[v]))
+	}
+	return res
+}
+
+// Difference returns a new multiset where counts of "other" are subtracted. Counts don't go below zero.
+func (m *Multiset[T]) Difference(other *Multiset[T]) *Multiset[T] {
+	res := New[T]()
+	for v, c := range m.counts {
+		res.Add(v, c-other.counts[v])
+	}
+	return res
+}
+
+// SubsetOf checks that every count of this multiset is not bigger than the count in "other".
+func (m *Multiset[T]) SubsetOf(other *Multiset[T]) bool {
+	if m.len > other.len {
+		return false
+	}
+	for v, c := range m.counts {
+		if c > other.counts[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both multisets have same elements with same counts.
+func (m *Multiset[T]) Equal(other *Multiset[T]) bool {
+	return m.len == other.len && maps.Equal(m.counts, other.counts)
+}
+
+// Clone returns a new multiset with same elements and counts.
+func (m *Multiset[T]) Clone() *Multiset[T] {
+	res := &Multiset[T]{counts: maps.Clone(m.counts), len: m.len}
+	if res.counts == nil {
+		res.counts = make(map[T]int)
+	}
+	return res
+}
+
+// Clear removes all elements.
+func (m *Multiset[T]) Clear() {
+	m.counts = make(map[T]int)
+	m.len = 0
+}
@@ -0,0 +1,117 @@
+package multiset
+
+import (
+	"github.com/stretchr/testify/suite"
+	"maps"
+	"slices"
+	"testing"
+)
+
+type multisetTestSuite struct {
+	suite.Suite
+}
+
+func TestMultiset(t *testing.T) {
+	suite.Run(t, new(multisetTestSuite))
+}
+
+func (suite *multisetTestSuite) TestAddRemove() {
+	m := New("a", "b", "a")
+	suite.Require().Equal(3, m.Len())
+	suite.Require().Equal(2, m.DistinctLen())
+	suite.Require().Equal(2, m.Count("a"))
+	suite.Require().Equal(0, m.Count("c"))
+
+	m.Add("c", 3)
+	m.Add("c", 0)
+	m.Add("c", -1)
+	suite.Require().Equal(3, m.Count("c"))
+	suite.Require().Equal(6, m.Len())
+
+	suite.Require().Equal(2, m.Remove("c", 2))
+	suite.Require().Equal(1, m.Count("c"))
+	suite.Require().Equal(1, m.Remove("c", 5))
+	suite.Require().False(m.Contains("c"))
+	suite.Require().Equal(0, m.Remove("c", 1))
+	suite.Require().Equal(0, m.Remove("a", 0))
+	suite.Require().Equal(2, m.RemoveAll("a"))
+	suite.Require().Equal(1, m.Len())
+	suite.Require().Equal(1, m.DistinctLen())
+
+	m.Clear()
+	suite.Require().True(m.IsEmpty())
+	suite.Require().Equal(0, m.DistinctLen())
+}
+
+func (suite *multisetTestSuite) TestIterators() {
+	m := New(1, 2, 2, 3, 3, 3)
+	suite.Require().Equal([]int{1, 2, 2, 3, 3, 3}, slices.Sorted(m.Seq()))
+	suite.Require().Equal(map[int]int{1: 1, 2: 2, 3: 3}, maps.Collect(m.Seq2()))
+	suite.Require().Equal([]int{1, 2, 3}, slices.Sorted(m.Distinct().Seq()))
+
+	count := 0
+	for range m.Seq() {
+		count++
+		break
+	}
+	suite.Require().Equal(1, count)
+	for range m.Seq2() {
+		count++
+		break
+	}
+	suite.Require().Equal(2, count)
+}
+
+func (suite *multisetTestSuite) TestMostCommon() {
+	m := New[string]()
+	m.Add("x", 5)
+	m.Add("y", 1)
+	m.Add("z", 3)
+	suite.Require().Equal([]Entry[string]{{"x", 5}, {"z", 3}}, m.MostCommon(2))
+	suite.Require().Equal([]Entry[string]{{"x", 5}, {"z", 3}, {"y", 1}}, m.MostCommon(0))
+	suite.Require().Len(m.MostCommon(10), 3)
+	suite.Require().Empty(New[int]().MostCommon(3))
+}
+
+func (suite *multisetTestSuite) TestAlgebra() {
+	left := New(1, 1, 1, 2, 3, 3)
+	right := New(1, 2, 2, 4)
+
+	suite.Require().Equal(map[int]int{1: 3, 2: 2, 3: 2, 4: 1}, maps.Collect(left.Union(right).Seq2()))
+	suite.Require().Equal(8, left.Union(right).Len())
+	suite.Require().Equal(map[int]int{1: 4, 2: 3, 3: 2, 4: 1}, maps.Collect(left.Sum(right).Seq2()))
+	suite.Require().Equal(10, left.Sum(right).Len())
+	inter := left.Intersection(right)
+	suite.Require().Equal(map[int]int{1: 1, 2: 1}, maps.Collect(inter.Seq2()))
+	suite.Require().Equal(2, inter.Len())
+	suite.Require().Equal(map[int]int{1: 2, 3: 2}, maps.Collect(left.Difference(right).Seq2()))
+	suite.Require().Equal(map[int]int{2: 1, 4: 1}, maps.Collect(right.Difference(left).Seq2()))
+
+	// operands are not changed
+	suite.Require().Equal(6, left.Len())
+	suite.Require().Equal(4, right.Len())
+}
+
+func (suite *multisetTestSuite) TestCompare() {
+	m := New(1, 2, 2)
+	suite.Require().True(m.SubsetOf(New(2, 1, 2, 3)))
+	suite.Require().False(m.SubsetOf(New(1, 2, 3)))
+	suite.Require().True(m.Equal(New(2, 2, 1)))
+	suite.Require().False(m.Equal(New(1, 2)))
+	suite.Require().False(m.Equal(New(1, 1, 2)))
+
+	c := m.Clone()
+	c.Add(5, 1)
+	suite.Require().False(m.Contains(5))
+}
+
+func (suite *multisetTestSuite) TestZeroValue() {
+	var m Multiset[int]
+	suite.Require().Equal(0, m.Count(1))
+	suite.Require().Equal(0, m.Remove(1, 1))
+	suite.Require().Empty(slices.Collect(m.Seq()))
+	suite.Require().Equal(0, m.Clone().Len())
+	suite.Require().True(m.Equal(New[int]()))
+	m.Add(1, 2)
+	suite.Require().Equal(2, m.Len())
+}
@@ -0,0 +1,96 @@
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrFull is returned when the queue reached its limit and can't accept new elements.
+var ErrFull = errors.New("queue is full")
+
+// FromChan reads values from the channel and enqueues them until the channel is closed.
+// Returns number of enqueued elements. If the context is cancelled returns ctx.Err().
+// If the queue reached its limit, FromChan stops before reading the next value and returns ErrFull,
+// so no value is lost. The queue is not thread-safe: do not use it from other goroutines until FromChan returns.
+func (q *Queue[QT]) FromChan(ctx context.Context, in <-chan QT) (int, error) {
+	count := 0
+	for {
+		if q.IsFull() {
+			return count, ErrFull
+		}
+		select {
+		case <-ctx.Done():
+			return count, ctx.Err()
+		case v, ok := <-in:
+			if !ok {
+				return count, nil
+			}
+			q.Enqueue(v)
+			count++
+		}
+	}
+}
+
+// ToChan returns a channel which receives the queue elements in FIFO order.
+// An element is removed from the queue only after it was received from the channel (backpressure),
+// so cancelling the context never loses elements. The channel is closed when the queue is empty
+// or the context is cancelled. No element is sent after the cancellation is noticed, but a receive which runs
+// at the same time as the cancellation may still get one element (it is dequeued as usual).
+// The queue is not thread-safe: do not use it until the channel is closed.
+func (q *Queue[QT]) ToChan(ctx context.Context) <-chan QT {
+	out := make(chan QT)
+	go func() {
+		defer close(out)
+		for {
+			v, exists := q.Peek()
+			if !exists || ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+				q.Dequeue()
+			}
+		}
+	}()
+	return out
+}
+
+// Unbounded returns a pair of channels connected by a queue without limit.
+// Sending to 'in' never waits for the receiver. After 'in' is closed all buffered values are
+// delivered to 'out' and then 'out' is closed. When the context is cancelled 'out' is closed
+// and buffered values are dropped (a receive which runs at the same time as the cancellation
+// may still get one value); stop sending to 'in' after that.
+func Unbounded[T comparable](ctx context.Context) (chan<- T, <-chan T) {
+	in := make(chan T)
+	out := make(chan T)
+	go func(recv <-chan T) {
+		defer close(out)
+		q := New[T]()
+		for recv != nil || !q.IsEmpty() {
+			if ctx.Err() != nil {
+				return
+			}
+			// nil channel blocks forever, so the case is disabled when nothing to send
+			var send chan T
+			next, exists := q.Peek()
+			if exists {
+				send = out
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-recv:
+				if !ok {
+					recv = nil
+					continue
+				}
+				q.Enqueue(v)
+			case send <- next:
+				q.Dequeue()
+			}
+		}
+	}(in)
+	return in, out
+}
@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"context"
+	"github.com/HoskeOwl/ggstruct/list"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ChanTestSuite struct {
+	suite.Suite
+}
+
+func TestRunChanSuite(t *testing.T) {
+	suite.Run(t, new(ChanTestSuite))
+}
+
+func (suite *ChanTestSuite) TestFromChan() {
+	in := make(chan int, 3)
+	in <- 1
+	in <- 2
+	in <- 3
+	close(in)
+
+	q := New[int]()
+	n, err := q.FromChan(context.Background(), in)
+	suite.Require().NoError(err)
+	suite.Require().Equal(3, n)
+	suite.Require().True(q.data.Equal(list.New[int](1, 2, 3)))
+}
+
+func (suite *ChanTestSuite) TestFromChanLimit() {
+	in := make(chan int, 3)
+	in <- 1
+	in <- 2
+	in <- 3
+	close(in)
+
+	q := New[int]().WithLimit(2)
+	n, err := q.FromChan(context.Background(), in)
+	suite.Require().ErrorIs(err, ErrFull)
+	suite.Require().Equal(2, n)
+	suite.Require().True(q.data.Equal(list.New[int](1, 2)))
+	// the third value is still in the channel
+	v, ok := <-in
+	suite.Require().True(ok)
+	suite.Require().Equal(3, v)
+}
+
+func (suite *ChanTestSuite) TestFromChanCancel() {
+	in := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	q := New[int]()
+	n, err := q.FromChan(ctx, in)
+	suite.Require().ErrorIs(err, context.Canceled)
+	suite.Require().Equal(0, n)
+}
+
+func (suite *ChanTestSuite) TestToChan() {
+	q := New[int]()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+
+	var res []int
+	for v := range q.ToChan(context.Background()) {
+		res = append(res, v)
+	}
+	suite.Require().Equal([]int{1, 2, 3}, res)
+	suite.Require().True(q.IsEmpty())
+}
+
+func (suite *ChanTestSuite) TestToChanCancel() {
+	q := New[int]()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+
+	// cancelled before start: nothing is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range q.ToChan(ctx) {
+		suite.Fail("value received after cancel")
+	}
+	suite.Require().Equal(3, q.Len())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	out := q.ToChan(ctx)
+	received := []int{<-out}
+	cancel()
+	// a receive which runs at the same time as the cancellation may get one more value,
+	// but every value is either received or still in the queue
+	for v := range out {
+		received = append(received, v)
+	}
+	suite.Require().LessOrEqual(len(received), 2)
+	suite.Require().Equal(3, len(received)+q.Len())
+	for i, v := range received {
+		suite.Require().Equal(i+1, v)
+	}
+	v, _ := q.Peek()
+	suite.Require().Equal(len(received)+1, v)
+}
+
+func (suite *ChanTestSuite) TestUnbounded() {
+	in, out := Unbounded[int](context.Background())
+	for i := 0; i < 1000; i++ {
+		in <- i
+	}
+	close(in)
+
+	expected := 0
+	for v := range out {
+		suite.Require().Equal(expected, v)
+		expected++
+	}
+	suite.Require().Equal(1000, expected)
+}
+
+func (suite *ChanTestSuite) TestUnboundedCancel() {
+	ctx, cancel := context.WithCancel(context.Background())
+	in, out := Unbounded[int](ctx)
+	in <- 1
+	in <- 2
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range out {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("out channel is not closed after cancel")
+	}
+}
@@ -0,0 +1,228 @@
+package queue
+
+import (
+	"slices"
+)
+
+type fairEntry[T comparable] struct {
+	queue   *Queue[T]
+	weight  int
+	deficit int
+}
+
+// FairQueue keeps a separate FIFO queue for every key and dequeues from them in turn,
+// so one busy key can't starve others. Every key is served up to its weight elements
+// per round (deficit round robin with unit cost). Default weight is 1 (plain round robin).
+// A drained sub-queue with default settings is forgotten, so short-lived keys don't accumulate.
+// The zero value is an empty queue ready to use.
+type FairQueue[K comparable, T comparable] struct {
+	entries map[K]*fairEntry[T]
+	// keys with not empty sub-queues in the serving order
+	ring  []K
+	pos   int
+	len   int
+	limit int
+}
+
+// NewFair returns new fair queue instance.
+func NewFair[K comparable, T comparable]() *FairQueue[K, T] {
+	return &FairQueue[K, T]{
+		entries: make(map[K]*fairEntry[T]),
+	}
+}
+
+// WithLimit sets the default maximum number of elements for sub-queues created after this call
+// and returns pointer to itself. limit=0 mens no limits.
+func (q *FairQueue[K, T]) WithLimit(limit int) *FairQueue[K, T] {
+	q.limit = limit
+	return q
+}
+
+// Limit returns the default limit of new sub-queues.
+func (q *FairQueue[K, T]) Limit() int {
+	if q.limit < 0 {
+		return 0
+	}
+	return q.limit
+}
+
+func (q *FairQueue[K, T]) entry(key K) *fairEntry[T] {
+	if q.entries == nil {
+		q.entries = make(map[K]*fairEntry[T])
+	}
+	e, exists := q.entries[key]
+	if !exists {
+		e = &fairEntry[T]{queue: New[T]().WithLimit(q.limit), weight: 1}
+		q.entries[key] = e
+	}
+	return e
+}
+
+// release forgets the sub-queue of the key if it is empty and has default settings.
+func (q *FairQueue[K, T]) release(key K, e *fairEntry[T]) {
+	if e.queue.IsEmpty() && e.weight == 1 && e.queue.Limit() == q.Limit() {
+		delete(q.entries, key)
+	}
+}
+
+// SetLimit sets the maximum number of elements in the sub-queue of the key. limit=0 mens no limits.
+func (q *FairQueue[K, T]) SetLimit(key K, limit int) {
+	e := q.entry(key)
+	e.queue.WithLimit(limit)
+	q.release(key, e)
+}
+
+// KeyLimit returns the limit of the sub-queue of the key.
+func (q *FairQueue[K, T]) KeyLimit(key K) int {
+	if e, exists := q.entries[key]; exists {
+		return e.queue.Limit()
+	}
+	return q.Limit()
+}
+
+// SetWeight sets how many elements of the key are dequeued in one round. Weight below 1 means 1.
+func (q *FairQueue[K, T]) SetWeight(key K, weight int) {
+	if weight < 1 {
+		weight = 1
+	}
+	e := q.entry(key)
+	e.weight = weight
+	if e.deficit > weight {
+		e.deficit = weight
+	}
+	q.release(key, e)
+}
+
+// Weight returns the weight of the key.
+func (q *FairQueue[K, T]) Weight(key K) int {
+	if e, exists := q.entries[key]; exists {
+		return e.weight
+	}
+	return 1
+}
+
+// Enqueue adds a new element to the sub-queue of the key.
+// Returns 'false' if the sub-queue reached its limit.
+func (q *FairQueue[K, T]) Enqueue(key K, value T) bool {
+	e := q.entry(key)
+	wasEmpty := e.queue.IsEmpty()
+	if !e.queue.Enqueue(value) {
+		return false
+	}
+	if wasEmpty {
+		q.ring = append(q.ring, key)
+	}
+	q.len++
+	return true
+}
+
+// Dequeue gets and removes the next element. Keys are served in turn according to their weights.
+func (q *FairQueue[K, T]) Dequeue() (key K, value T, exists bool) {
+	if len(q.ring) == 0 {
+		return
+	}
+
+	key = q.ring[q.pos]
+	e := q.entries[key]
+	if e.deficit == 0 {
+		e.deficit = e.weight
+	}
+	value, exists = e.queue.Dequeue()
+	e.deficit--
+	q.len--
+
+	if e.queue.IsEmpty() {
+		e.deficit = 0
+		q.removeFromRing(q.pos)
+		q.release(key, e)
+	} else if e.deficit == 0 {
+		q.pos = (q.pos + 1) % len(q.ring)
+	}
+	return
+}
+
+func (q *FairQueue[K, T]) removeFromRing(idx int) {
+	q.ring = slices.Delete(q.ring, idx, idx+1)
+	if idx < q.pos {
+		q.pos--
+	}
+	if q.pos >= len(q.ring) {
+		q.pos = 0
+	}
+}
+
+// Peek returns the element which will be dequeued next without removing it.
+func (q *FairQueue[K, T]) Peek() (key K, value T, exists bool) {
+	if len(q.ring) == 0 {
+		return
+	}
+	key = q.ring[q.pos]
+	value, exists = q.entries[key].queue.Peek()
+	return
+}
+
+// DequeueKey gets and removes the next element of the key ignoring the serving order.
+func (q *FairQueue[K, T]) DequeueKey(key K) (value T, exists bool) {
+	e, found := q.entries[key]
+	if !found {
+		return
+	}
+	value, exists = e.queue.Dequeue()
+	if !exists {
+		return
+	}
+	q.len--
+	if e.queue.IsEmpty() {
+		e.deficit = 0
+		q.removeFromRing(slices.Index(q.ring, key))
+		q.release(key, e)
+	}
+	return
+}
+
+// Drop removes the sub-queue of the key with all elements and settings.
+// Returns the number of removed elements.
+func (q *FairQueue[K, T]) Drop(key K) int {
+	e, exists := q.entries[key]
+	if !exists {
+		return 0
+	}
+	n := e.queue.Len()
+	if n > 0 {
+		q.removeFromRing(slices.Index(q.ring, key))
+	}
+	q.len -= n
+	delete(q.entries, key)
+	return n
+}
+
+// Len returns the number of elements in all sub-queues.
+func (q *FairQueue[K, T]) Len() int {
+	return q.len
+}
+
+// KeyLen returns the number of elements in the sub-queue of the key.
+func (q *FairQueue[K, T]) KeyLen(key K) int {
+	if e, exists := q.entries[key]; exists {
+		return e.queue.Len()
+	}
+	return 0
+}
+
+// Keys returns the number of keys with not empty sub-queues.
+func (q *FairQueue[K, T]) Keys() int {
+	return len(q.ring)
+}
+
+// IsEmpty returns 'true' if no elements in all sub-queues.
+func (q *FairQueue[K, T]) IsEmpty() bool {
+	return q.len == 0
+}
+
+// Clear removes all elements and sub-queues.
+func (q *FairQueue[K, T]) Clear() {
+	q.entries = make(map[K]*fairEntry[T])
+	q.ring = nil
+	q.pos = 0
+	q.len = 0
+}
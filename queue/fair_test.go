@@ -0,0 +1,191 @@
+package queue
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type FairQueueTestSuite struct {
+	suite.Suite
+}
+
+func TestRunFairQueueSuite(t *testing.T) {
+	suite.Run(t, new(FairQueueTestSuite))
+}
+
+func dequeueAll[K comparable, T comparable](q *FairQueue[K, T]) ([]K, []T) {
+	var keys []K
+	var values []T
+	for {
+		k, v, exists := q.Dequeue()
+		if !exists {
+			return keys, values
+		}
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+}
+
+func (suite *FairQueueTestSuite) TestEmpty() {
+	q := NewFair[string, int]()
+	suite.Require().True(q.IsEmpty())
+	suite.Require().Equal(0, q.Len())
+	_, _, exists := q.Dequeue()
+	suite.Require().False(exists)
+	_, _, exists = q.Peek()
+	suite.Require().False(exists)
+	suite.Require().Equal(0, q.KeyLen("a"))
+}
+
+func (suite *FairQueueTestSuite) TestRoundRobin() {
+	q := NewFair[string, int]()
+	for i := 1; i <= 4; i++ {
+		q.Enqueue("a", i)
+	}
+	q.Enqueue("b", 10)
+	q.Enqueue("b", 20)
+	q.Enqueue("c", 100)
+	suite.Require().Equal(7, q.Len())
+	suite.Require().Equal(4, q.KeyLen("a"))
+	suite.Require().Equal(3, q.Keys())
+
+	k, v, exists := q.Peek()
+	suite.Require().True(exists)
+	suite.Require().Equal("a", k)
+	suite.Require().Equal(1, v)
+
+	keys, values := dequeueAll(q)
+	suite.Require().Equal([]string{"a", "b", "c", "a", "b", "a", "a"}, keys)
+	suite.Require().Equal([]int{1, 10, 100, 2, 20, 3, 4}, values)
+	suite.Require().True(q.IsEmpty())
+	suite.Require().Equal(0, q.Keys())
+}
+
+func (suite *FairQueueTestSuite) TestWeights() {
+	q := NewFair[string, int]()
+	q.SetWeight("a", 3)
+	q.SetWeight("b", 0)
+	suite.Require().Equal(3, q.Weight("a"))
+	suite.Require().Equal(1, q.Weight("b"))
+	suite.Require().Equal(1, q.Weight("unknown"))
+	for i := 0; i < 5; i++ {
+		q.Enqueue("a", i)
+		q.Enqueue("b", i)
+	}
+
+	keys, _ := dequeueAll(q)
+	suite.Require().Equal([]string{"a", "a", "a", "b", "a", "a", "b", "b", "b", "b"}, keys)
+}
+
+func (suite *FairQueueTestSuite) TestLateKey() {
+	q := NewFair[string, int]()
+	q.Enqueue("a", 1)
+	q.Enqueue("a", 2)
+	q.Dequeue()
+	q.Enqueue("b", 1)
+	q.Enqueue("a", 3)
+
+	keys, values := dequeueAll(q)
+	suite.Require().Equal([]string{"a", "b", "a"}, keys)
+	suite.Require().Equal([]int{2, 1, 3}, values)
+}
+
+func (suite *FairQueueTestSuite) TestLimit() {
+	q := NewFair[string, int]().WithLimit(2)
+	suite.Require().Equal(2, q.Limit())
+	q.SetLimit("b", 1)
+	suite.Require().Equal(1, q.KeyLimit("b"))
+	suite.Require().Equal(2, q.KeyLimit("a"))
+
+	suite.Require().True(q.Enqueue("a", 1))
+	suite.Require().True(q.Enqueue("a", 2))
+	suite.Require().False(q.Enqueue("a", 3))
+	suite.Require().True(q.Enqueue("b", 1))
+	suite.Require().False(q.Enqueue("b", 2))
+	suite.Require().Equal(3, q.Len())
+	suite.Require().Equal(2, q.KeyLen("a"))
+	suite.Require().Equal(1, q.KeyLen("b"))
+}
+
+func (suite *FairQueueTestSuite) TestDequeueKey() {
+	q := NewFair[string, int]()
+	q.Enqueue("a", 1)
+	q.Enqueue("b", 2)
+	q.Enqueue("c", 3)
+
+	v, exists := q.DequeueKey("b")
+	suite.Require().True(exists)
+	suite.Require().Equal(2, v)
+	_, exists = q.DequeueKey("b")
+	suite.Require().False(exists)
+	_, exists = q.DequeueKey("unknown")
+	suite.Require().False(exists)
+	suite.Require().Equal(2, q.Len())
+
+	keys, _ := dequeueAll(q)
+	suite.Require().Equal([]string{"a", "c"}, keys)
+}
+
+func (suite *FairQueueTestSuite) TestDrop() {
+	q := NewFair[string, int]()
+	q.SetWeight("a", 2)
+	q.Enqueue("a", 1)
+	q.Enqueue("a", 2)
+	q.Enqueue("a", 3)
+	q.Enqueue("b", 1)
+	q.Enqueue("c", 1)
+	q.Dequeue()
+
+	suite.Require().Equal(0, q.Drop("unknown"))
+	suite.Require().Equal(2, q.Drop("a"))
+	suite.Require().Equal(2, q.Len())
+	suite.Require().Equal(0, q.KeyLen("a"))
+	suite.Require().Equal(1, q.Weight("a"))
+
+	keys, _ := dequeueAll(q)
+	suite.Require().Equal([]string{"b", "c"}, keys)
+
+	q.Enqueue("a", 1)
+	q.Clear()
+	suite.Require().True(q.IsEmpty())
+	_, _, exists := q.Dequeue()
+	suite.Require().False(exists)
+}
+
+func (suite *FairQueueTestSuite) TestForgetDrainedKeys() {
+	q := NewFair[int, int]().WithLimit(5)
+	for key := 0; key < 100; key++ {
+		q.Enqueue(key, key)
+		q.Enqueue(key, key)
+	}
+	dequeueAll(q)
+	suite.Require().Empty(q.entries)
+
+	for key := 0; key < 10; key++ {
+		q.Enqueue(key, key)
+		q.DequeueKey(key)
+	}
+	suite.Require().Empty(q.entries)
+
+	// custom settings are kept while set
+	q.SetWeight(1, 3)
+	q.SetLimit(2, 1)
+	q.Enqueue(1, 10)
+	q.Enqueue(2, 20)
+	dequeueAll(q)
+	suite.Require().Len(q.entries, 2)
+	suite.Require().Equal(3, q.Weight(1))
+	suite.Require().Equal(1, q.KeyLimit(2))
+
+	// back to defaults
+	q.SetWeight(1, 1)
+	q.SetLimit(2, 5)
+	suite.Require().Empty(q.entries)
+	suite.Require().Equal(1, q.Weight(1))
+	suite.Require().Equal(5, q.KeyLimit(2))
+
+	// a key with elements is kept
+	q.Enqueue(3, 30)
+	q.SetWeight(3, 1)
+	suite.Require().Equal(1, q.KeyLen(3))
+}
@@ -0,0 +1,229 @@
+package queue
+
+import (
+	"expvar"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/HoskeOwl/ggstruct/list"
+)
+
+// waitSamples is the number of the latest time-in-queue values used for percentiles.
+const waitSamples = 1024
+
+// Hooks are optional callbacks called by the queue. Nil callbacks are skipped.
+type Hooks[T comparable] struct {
+	// OnEnqueue is called after the value was added.
+	OnEnqueue func(value T)
+	// OnDequeue is called after the value was dequeued.
+	OnDequeue func(value T)
+	// OnReject is called when the value wasn't added because of the limit.
+	OnReject func(value T)
+	// OnClear is called after Clear with the number of removed elements.
+	OnClear func(count int)
+}
+
+// Stats is a snapshot of the queue counters.
+type Stats struct {
+	Len       int
+	HighWater int
+	Enqueued  uint64
+	Dequeued  uint64
+	Deleted   uint64
+	Rejected  uint64
+	// Percentiles of time-in-queue of the latest dequeued elements. Zero if timestamps are disabled.
+	WaitP50 time.Duration
+	WaitP90 time.Duration
+	WaitP99 time.Duration
+}
+
+type observer[T comparable] struct {
+	hooks Hooks[T]
+
+	// stats are guarded by the mutex, so Stats can be read from another goroutine (e.g. expvar).
+	// Len is tracked even if stats are disabled.
+	mu      sync.Mutex
+	enabled bool
+	stats   Stats
+	// enqueue time of every element in the queue order, nil if timestamps are disabled
+	times   *list.List[time.Time]
+	waits   []time.Duration
+	waitPos int
+	now     func() time.Time
+}
+
+// WithHooks sets callbacks for the queue events and returns pointer to itself.
+func (q *Queue[QT]) WithHooks(hooks Hooks[QT]) *Queue[QT] {
+	q.observer().hooks = hooks
+	return q
+}
+
+// WithStats enables counters available through Stats and returns pointer to itself.
+// With timestamps=true the enqueue time of every element is stored to calculate time-in-queue percentiles.
+func (q *Queue[QT]) WithStats(timestamps bool) *Queue[QT] {
+	o := q.observer()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.enabled = true
+	o.stats.Len = q.data.Len()
+	o.stats.HighWater = max(o.stats.HighWater, o.stats.Len)
+	if timestamps && o.times == nil {
+		// existing elements are counted from now
+		o.times = list.New[time.Time]()
+		now := o.now()
+		for i := 0; i < q.data.Len(); i++ {
+			o.times.PushBack(now)
+		}
+	} else if !timestamps {
+		o.times = nil
+		o.waits = nil
+		o.waitPos = 0
+	}
+	return q
+}
+
+// Stats returns a snapshot of the queue counters. Without WithStats only Len is filled.
+// Safe to call from another goroutine if WithStats, WithHooks or ExpvarFunc was called before the queue was shared.
+func (q *Queue[QT]) Stats() Stats {
+	if q.obs == nil {
+		return Stats{Len: q.Len()}
+	}
+	return q.obs.snapshot()
+}
+
+// ExpvarFunc returns expvar.Func with the queue Stats. Publish it with expvar.Publish.
+// The function is safe to call from another goroutine (call ExpvarFunc itself before the queue is shared).
+func (q *Queue[QT]) ExpvarFunc() expvar.Func {
+	o := q.observer()
+	return func() any {
+		return o.snapshot()
+	}
+}
+
+func (q *Queue[QT]) observer() *observer[QT] {
+	if q.obs == nil {
+		q.obs = &observer[QT]{now: time.Now}
+		q.obs.stats.Len = q.data.Len()
+	}
+	return q.obs
+}
+
+func (o *observer[T]) enqueued(value T, length int) {
+	o.mu.Lock()
+	o.stats.Len = length
+	if o.enabled {
+		o.stats.Enqueued++
+		if length > o.stats.HighWater {
+			o.stats.HighWater = length
+		}
+		if o.times != nil {
+			o.times.PushBack(o.now())
+		}
+	}
+	o.mu.Unlock()
+	if o.hooks.OnEnqueue != nil {
+		o.hooks.OnEnqueue(value)
+	}
+}
+
+func (o *observer[T]) dequeued(value T, length int) {
+	o.mu.Lock()
+	o.stats.Len = length
+	if o.enabled {
+		o.stats.Dequeued++
+		if o.times != nil {
+			if t, exists := o.times.PopFront(); exists {
+				o.addWait(o.now().Sub(t))
+			}
+		}
+	}
+	o.mu.Unlock()
+	if o.hooks.OnDequeue != nil {
+		o.hooks.OnDequeue(value)
+	}
+}
+
+func (o *observer[T]) deleted(index int, length int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stats.Len = length
+	if !o.enabled {
+		return
+	}
+	o.stats.Deleted++
+	if o.times != nil {
+		o.times.PopAt(index)
+	}
+}
+
+func (o *observer[T]) rejected(value T) {
+	o.mu.Lock()
+	if o.enabled {
+		o.stats.Rejected++
+	}
+	o.mu.Unlock()
+	if o.hooks.OnReject != nil {
+		o.hooks.OnReject(value)
+	}
+}
+
+func (o *observer[T]) cleared(count int) {
+	o.mu.Lock()
+	o.stats.Len = 0
+	if o.enabled && o.times != nil {
+		o.times = list.New[time.Time]()
+	}
+	o.mu.Unlock()
+	if o.hooks.OnClear != nil {
+		o.hooks.OnClear(count)
+	}
+}
+
+// clone copies settings and enqueue times, counters start from zero.
+func (o *observer[T]) clone(length int) *observer[T] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	c := &observer[T]{hooks: o.hooks, enabled: o.enabled, now: o.now}
+	c.stats.Len = length
+	c.stats.HighWater = length
+	if o.times != nil {
+		c.times = o.times.Clone()
+	}
+	return c
+}
+
+func (o *observer[T]) addWait(d time.Duration) {
+	if len(o.waits) < waitSamples {
+		o.waits = append(o.waits, d)
+		return
+	}
+	o.waits[o.waitPos] = d
+	o.waitPos = (o.waitPos + 1) % waitSamples
+}
+
+func (o *observer[T]) snapshot() Stats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.enabled {
+		return Stats{Len: o.stats.Len}
+	}
+	res := o.stats
+	if len(o.waits) > 0 {
+		waits := slices.Clone(o.waits)
+		slices.Sort(waits)
+		res.WaitP50 = percentile(waits, 50)
+		res.WaitP90 = percentile(waits, 90)
+		res.WaitP99 = percentile(waits, 99)
+	}
+	return res
+}
+
+// percentile returns nearest-rank percentile of sorted values.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	idx := (len(sorted)*p + 99) / 100
+	if idx > 0 {
+		idx--
+	}
+	return sorted[idx]
+}
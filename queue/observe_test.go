@@ -0,0 +1,188 @@
+package queue
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+	"time"
+)
+
+type ObserveTestSuite struct {
+	suite.Suite
+}
+
+func TestRunObserveSuite(t *testing.T) {
+	suite.Run(t, new(ObserveTestSuite))
+}
+
+func (suite *ObserveTestSuite) TestDisabled() {
+	q := New[int]()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	suite.Require().Nil(q.obs)
+	suite.Require().Equal(Stats{Len: 2}, q.Stats())
+}
+
+func (suite *ObserveTestSuite) TestHooks() {
+	var enqueued, dequeued, rejected []int
+	cleared := -1
+	q := New[int]().WithLimit(2).WithHooks(Hooks[int]{
+		OnEnqueue: func(v int) { enqueued = append(enqueued, v) },
+		OnDequeue: func(v int) { dequeued = append(dequeued, v) },
+		OnReject:  func(v int) { rejected = append(rejected, v) },
+		OnClear:   func(n int) { cleared = n },
+	})
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+	q.Dequeue()
+	q.Enqueue(4)
+	q.Clear()
+	q.Dequeue()
+
+	suite.Require().Equal([]int{1, 2, 4}, enqueued)
+	suite.Require().Equal([]int{1}, dequeued)
+	suite.Require().Equal([]int{3}, rejected)
+	suite.Require().Equal(2, cleared)
+	// hooks only, no counters
+	suite.Require().Equal(Stats{Len: 0}, q.Stats())
+
+	// partial hooks
+	q = New[int]().WithHooks(Hooks[int]{OnClear: func(n int) { cleared = n }})
+	q.Enqueue(1)
+	q.Dequeue()
+	q.Clear()
+	suite.Require().Equal(0, cleared)
+}
+
+func (suite *ObserveTestSuite) TestCounters() {
+	q := New[int]().WithLimit(3).WithStats(false)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+	q.Enqueue(4)
+	q.Dequeue()
+	q.Delete(3)
+	q.Delete(10)
+	q.Enqueue(5)
+
+	suite.Require().Equal(Stats{
+		Len:       2,
+		HighWater: 3,
+		Enqueued:  4,
+		Dequeued:  1,
+		Deleted:   1,
+		Rejected:  1,
+	}, q.Stats())
+
+	q.Clear()
+	st := q.Stats()
+	suite.Require().Equal(0, st.Len)
+	suite.Require().Equal(3, st.HighWater)
+}
+
+func (suite *ObserveTestSuite) TestWaitPercentiles() {
+	now := time.Unix(0, 0)
+	q := New[int]().WithStats(true)
+	q.obs.now = func() time.Time { return now }
+
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+	// element i waits i+1 seconds
+	for i := 0; i < 100; i++ {
+		now = now.Add(time.Second)
+		q.Dequeue()
+	}
+	st := q.Stats()
+	suite.Require().Equal(50*time.Second, st.WaitP50)
+	suite.Require().Equal(90*time.Second, st.WaitP90)
+	suite.Require().Equal(99*time.Second, st.WaitP99)
+}
+
+func (suite *ObserveTestSuite) TestDeleteKeepsTimestamps() {
+	now := time.Unix(0, 0)
+	q := New[int]().WithStats(true)
+	q.obs.now = func() time.Time { return now }
+
+	q.Enqueue(1)
+	now = now.Add(time.Second)
+	q.Enqueue(2)
+	now = now.Add(time.Second)
+	q.Enqueue(3)
+	q.Delete(2)
+	now = now.Add(time.Second)
+	q.Dequeue()
+	q.Dequeue()
+
+	st := q.Stats()
+	suite.Require().Equal(3*time.Second, st.WaitP99)
+	suite.Require().Equal(time.Second, st.WaitP50)
+}
+
+func (suite *ObserveTestSuite) TestEnableOnFilledQueue() {
+	q := New[int]()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.WithStats(true)
+	suite.Require().Equal(2, q.obs.times.Len())
+	suite.Require().Equal(2, q.Stats().HighWater)
+
+	c := q.Clone()
+	suite.Require().Equal(2, c.obs.times.Len())
+	suite.Require().Equal(Stats{Len: 2, HighWater: 2}, c.Stats())
+
+	q.WithStats(false)
+	suite.Require().Nil(q.obs.times)
+}
+
+func (suite *ObserveTestSuite) TestExpvar() {
+	q := New[int]().WithStats(false)
+	q.Enqueue(1)
+
+	data, err := json.Marshal(q.ExpvarFunc().Value())
+	suite.Require().NoError(err)
+	var st Stats
+	suite.Require().NoError(json.Unmarshal(data, &st))
+	suite.Require().Equal(1, st.Len)
+	suite.Require().Equal(uint64(1), st.Enqueued)
+}
+
+// TestConcurrentStats polls stats while the owner goroutine works with the queue, run it with -race.
+func (suite *ObserveTestSuite) TestConcurrentStats() {
+	for _, q := range []*Queue[int]{New[int](), New[int]().WithStats(true), New[int]().WithHooks(Hooks[int]{})} {
+		f := q.ExpvarFunc()
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					f()
+					q.Stats()
+				}
+			}
+		}()
+		for i := 0; i < 2000; i++ {
+			q.Enqueue(i)
+			if i%3 == 0 {
+				q.Dequeue()
+			}
+			if i%100 == 0 {
+				q.Delete(i)
+			}
+			if i%500 == 0 {
+				q.Clear()
+			}
+		}
+		close(done)
+		wg.Wait()
+		suite.Require().Equal(q.Len(), f().(Stats).Len)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"github.com/HoskeOwl/ggstruct/list"
 )
 
+// Queue is a FIFO queue. The zero value is an empty queue ready to use.
 type Queue[QT comparable] struct {
-	data  *list.List[QT]
+	data  list.List[QT]
 	limit int
+	// nil until hooks or stats are enabled
+	obs *observer[QT]
 }
 
 // WithLimit sets the maximum number of elements in the queue and returns pointer to itself.
@@ -28,27 +31,45 @@ func (q *Queue[QT]) Limit() int {
 // New returns new queue instance
 func New[T comparable]() *Queue[T] {
 	return &Queue[T]{
-		data:  list.New[T](),
 		limit: 0,
 	}
 }
 
 // Dequeue get and remove the next element from the queue
 func (q *Queue[QT]) Dequeue() (value QT, exists bool) {
-	return q.data.PopFront()
+	value, exists = q.data.PopFront()
+	if exists && q.obs != nil {
+		q.obs.dequeued(value, q.data.Len())
+	}
+	return
 }
 
 // Delete remove an element from the queue
 func (q *Queue[QT]) Delete(value QT) bool {
-	return q.data.Delete(value)
+	if q.obs == nil {
+		return q.data.Delete(value)
+	}
+	idx := q.data.Index(value)
+	if idx < 0 {
+		return false
+	}
+	q.data.PopAt(idx)
+	q.obs.deleted(idx, q.data.Len())
+	return true
 }
 
 // Enqueue add a new element to the queue
 func (q *Queue[QT]) Enqueue(value QT) bool {
 	if q.limit > 0 && q.data.Len()+1 > q.limit {
+		if q.obs != nil {
+			q.obs.rejected(value)
+		}
 		return false
 	}
 	q.data.PushBack(value)
+	if q.obs != nil {
+		q.obs.enqueued(value, q.data.Len())
+	}
 	return true
 }
 
@@ -69,15 +90,23 @@ func (q *Queue[QT]) Contains(value QT) bool {
 
 // Clear removes all elements from the queue
 func (q *Queue[QT]) Clear() {
-	q.data = list.New[QT]()
+	count := q.data.Len()
+	q.data = list.List[QT]{}
+	if q.obs != nil {
+		q.obs.cleared(count)
+	}
 }
 
-// Clone returns a new queue with the same elements
+// Clone returns a new queue with the same elements. Hooks and stats settings are copied, counters are not.
 func (q *Queue[QT]) Clone() *Queue[QT] {
-	return &Queue[QT]{
-		data:  q.data.Clone(),
+	c := &Queue[QT]{
+		data:  *q.data.Clone(),
 		limit: q.limit,
 	}
+	if q.obs != nil {
+		c.obs = q.obs.clone(c.data.Len())
+	}
+	return c
 }
 
 // IsFull returns 'true' if elements count equal or greater than limit. With limit <= 0 always returns false.
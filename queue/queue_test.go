@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"context"
 	"github.com/HoskeOwl/ggstruct/list"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -246,3 +247,119 @@ func (suite *QueueTestSuite) TestPeek() {
 	suite.Require().True(exists)
 	suite.Require().Equal(value, 7)
 }
+
+func (suite *QueueTestSuite) TestZeroValue() {
+	var q Queue[int]
+	suite.Require().Equal(0, q.Len())
+	suite.Require().True(q.IsEmpty())
+	suite.Require().False(q.IsFull())
+	suite.Require().Equal(0, q.Limit())
+	suite.Require().False(q.Contains(1))
+	suite.Require().False(q.Delete(1))
+	_, exists := q.Peek()
+	suite.Require().False(exists)
+	_, exists = q.Dequeue()
+	suite.Require().False(exists)
+	suite.Require().True(q.Clone().IsEmpty())
+	q.Clear()
+	suite.Require().True(q.Enqueue(1))
+	suite.Require().True(q.Contains(1))
+
+	var q2 Queue[int]
+	suite.Require().True(q2.Enqueue(1))
+	suite.Require().True(q2.Enqueue(2))
+	v, exists := q2.Dequeue()
+	suite.Require().True(exists)
+	suite.Require().Equal(1, v)
+
+	var q3 Queue[int]
+	q3.WithLimit(1)
+	suite.Require().True(q3.Enqueue(1))
+	suite.Require().False(q3.Enqueue(2))
+
+	var q4 Queue[int]
+	c := q4.Clone()
+	c.Enqueue(1)
+	suite.Require().Equal(0, q4.Len())
+
+	var q5 Queue[int]
+	suite.Require().Equal(Stats{}, q5.Stats())
+	suite.Require().Equal(Stats{}, q5.ExpvarFunc().Value())
+	q5.WithStats(true).WithHooks(Hooks[int]{})
+	q5.Enqueue(1)
+	suite.Require().Equal(uint64(1), q5.Stats().Enqueued)
+
+	var q6 Queue[int]
+	for range q6.ToChan(context.Background()) {
+		suite.Fail("should not be reached")
+	}
+	in := make(chan int, 1)
+	in <- 1
+	close(in)
+	n, err := q6.FromChan(context.Background(), in)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, n)
+}
+
+func (suite *QueueTestSuite) TestZeroValueUnique() {
+	var q UniqueQueue[int]
+	suite.Require().True(q.IsEmpty())
+	suite.Require().False(q.IsFull())
+	suite.Require().Equal(0, q.Limit())
+	suite.Require().False(q.Contains(1))
+	suite.Require().False(q.Delete(1))
+	_, exists := q.Peek()
+	suite.Require().False(exists)
+	_, exists = q.Dequeue()
+	suite.Require().False(exists)
+	suite.Require().True(q.Clone().IsEmpty())
+	q.Clear()
+
+	var q2 UniqueQueue[int]
+	q2.WithMoveToBack(true)
+	suite.Require().True(q2.Enqueue(1))
+	suite.Require().True(q2.Enqueue(2))
+	suite.Require().True(q2.Enqueue(1))
+	suite.Require().True(q2.Delete(2))
+	v, _ := q2.Dequeue()
+	suite.Require().Equal(1, v)
+	suite.Require().Equal(0, q2.Len())
+
+	var q3 UniqueQueue[int]
+	q3.WithLimit(1)
+	suite.Require().True(q3.Enqueue(1))
+	suite.Require().False(q3.Enqueue(2))
+}
+
+func (suite *QueueTestSuite) TestZeroValueFair() {
+	var q FairQueue[string, int]
+	suite.Require().True(q.IsEmpty())
+	suite.Require().Equal(0, q.Len())
+	suite.Require().Equal(0, q.Keys())
+	suite.Require().Equal(0, q.KeyLen("a"))
+	suite.Require().Equal(0, q.KeyLimit("a"))
+	suite.Require().Equal(1, q.Weight("a"))
+	suite.Require().Equal(0, q.Limit())
+	suite.Require().Equal(0, q.Drop("a"))
+	_, _, exists := q.Peek()
+	suite.Require().False(exists)
+	_, _, exists = q.Dequeue()
+	suite.Require().False(exists)
+	_, exists = q.DequeueKey("a")
+	suite.Require().False(exists)
+	q.Clear()
+
+	var q2 FairQueue[string, int]
+	q2.WithLimit(1)
+	suite.Require().True(q2.Enqueue("a", 1))
+	suite.Require().False(q2.Enqueue("a", 2))
+
+	var q3 FairQueue[string, int]
+	q3.SetWeight("a", 2)
+	q3.SetLimit("b", 1)
+	suite.Require().True(q3.Enqueue("a", 1))
+	k, v, exists := q3.Dequeue()
+	suite.Require().True(exists)
+	suite.Require().Equal("a", k)
+	suite.Require().Equal(1, v)
+}
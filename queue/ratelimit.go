@@ -0,0 +1,156 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrEmpty is returned when there is no element in the queue.
+var ErrEmpty = errors.New("queue is empty")
+
+// Clock provides current time and timers. Can be replaced in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RateLimited wraps a queue and dequeues elements no faster than the rate using a token bucket.
+// Safe for concurrent use, the wrapped queue must not be used directly.
+type RateLimited[QT comparable] struct {
+	mu     sync.Mutex
+	queue  *Queue[QT]
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+	clock  Clock
+}
+
+// NewRateLimited returns the queue wrapper which allows 'rate' dequeues per second
+// and up to 'burst' dequeues at once. rate <= 0 means no limits. burst below 1 means 1.
+// The bucket is full at the start.
+func NewRateLimited[T comparable](q *Queue[T], rate float64, burst int) *RateLimited[T] {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimited[T]{
+		queue:  q,
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		clock:  systemClock{},
+	}
+}
+
+// WithClock sets the clock and returns pointer to itself.
+func (r *RateLimited[QT]) WithClock(clock Clock) *RateLimited[QT] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clock = clock
+	r.last = time.Time{}
+	return r
+}
+
+// SetRate changes the rate and burst. Already collected tokens are kept up to the new burst.
+func (r *RateLimited[QT]) SetRate(rate float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refill()
+	r.rate = rate
+	r.burst = burst
+	r.tokens = min(r.tokens, float64(burst))
+}
+
+// Rate returns the current rate and burst.
+func (r *RateLimited[QT]) Rate() (float64, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rate, r.burst
+}
+
+// Enqueue add a new element to the queue
+func (r *RateLimited[QT]) Enqueue(value QT) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.queue.Enqueue(value)
+}
+
+// Len returns the number of items in the queue
+func (r *RateLimited[QT]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.queue.Len()
+}
+
+// Dequeue get and remove the next element if the rate allows it.
+// Returns 'false' if the queue is empty or there is no token now.
+func (r *RateLimited[QT]) Dequeue() (value QT, exists bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.queue.IsEmpty() || r.reserve() > 0 {
+		return
+	}
+	return r.queue.Dequeue()
+}
+
+// DequeueWait get and remove the next element, waits until the rate allows it.
+// Returns ErrEmpty if the queue is empty and ctx.Err() if the context is cancelled while waiting.
+func (r *RateLimited[QT]) DequeueWait(ctx context.Context) (value QT, err error) {
+	for {
+		r.mu.Lock()
+		if r.queue.IsEmpty() {
+			r.mu.Unlock()
+			return value, ErrEmpty
+		}
+		wait := r.reserve()
+		clock := r.clock
+		if wait == 0 {
+			value, _ = r.queue.Dequeue()
+			r.mu.Unlock()
+			return value, nil
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return value, ctx.Err()
+		case <-clock.After(wait):
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns time until the next token without taking it.
+func (r *RateLimited[QT]) reserve() time.Duration {
+	if r.rate <= 0 {
+		return 0
+	}
+	r.refill()
+	if r.tokens >= 1 {
+		r.tokens--
+		return 0
+	}
+	wait := time.Duration((1 - r.tokens) / r.rate * float64(time.Second))
+	if wait <= 0 {
+		// rounding
+		wait = 1
+	}
+	return wait
+}
+
+func (r *RateLimited[QT]) refill() {
+	now := r.clock.Now()
+	if !r.last.IsZero() && r.rate > 0 {
+		r.tokens = min(float64(r.burst), r.tokens+now.Sub(r.last).Seconds()*r.rate)
+	}
+	r.last = now
+}
@@ -0,0 +1,188 @@
+package queue
+
+import (
+	"context"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+	// receives a value on every After call
+	waiting chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1000, 0), waiting: make(chan struct{}, 100)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	c.waiting <- struct{}{}
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			timers = append(timers, t)
+		} else {
+			t.ch <- c.now
+		}
+	}
+	c.timers = timers
+}
+
+type RateLimitedTestSuite struct {
+	suite.Suite
+}
+
+func TestRunRateLimitedSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitedTestSuite))
+}
+
+func (suite *RateLimitedTestSuite) newFilled(rate float64, burst int, n int) (*RateLimited[int], *fakeClock) {
+	clock := newFakeClock()
+	q := New[int]()
+	for i := 0; i < n; i++ {
+		q.Enqueue(i)
+	}
+	return NewRateLimited(q, rate, burst).WithClock(clock), clock
+}
+
+func (suite *RateLimitedTestSuite) TestBurst() {
+	r, clock := suite.newFilled(2, 3, 10)
+
+	for i := 0; i < 3; i++ {
+		v, exists := r.Dequeue()
+		suite.Require().True(exists)
+		suite.Require().Equal(i, v)
+	}
+	_, exists := r.Dequeue()
+	suite.Require().False(exists)
+	suite.Require().Equal(7, r.Len())
+
+	clock.Advance(500 * time.Millisecond)
+	v, exists := r.Dequeue()
+	suite.Require().True(exists)
+	suite.Require().Equal(3, v)
+	_, exists = r.Dequeue()
+	suite.Require().False(exists)
+
+	// tokens are limited by burst
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		_, exists = r.Dequeue()
+		suite.Require().True(exists)
+	}
+	_, exists = r.Dequeue()
+	suite.Require().False(exists)
+}
+
+func (suite *RateLimitedTestSuite) TestEmptyKeepsTokens() {
+	r, _ := suite.newFilled(1, 1, 0)
+	_, exists := r.Dequeue()
+	suite.Require().False(exists)
+	r.Enqueue(5)
+	v, exists := r.Dequeue()
+	suite.Require().True(exists)
+	suite.Require().Equal(5, v)
+
+	_, err := r.DequeueWait(context.Background())
+	suite.Require().ErrorIs(err, ErrEmpty)
+}
+
+func (suite *RateLimitedTestSuite) TestNoLimit() {
+	r, _ := suite.newFilled(0, 1, 100)
+	for i := 0; i < 100; i++ {
+		_, exists := r.Dequeue()
+		suite.Require().True(exists)
+	}
+}
+
+func (suite *RateLimitedTestSuite) TestDequeueWait() {
+	r, clock := suite.newFilled(10, 1, 3)
+
+	v, err := r.DequeueWait(context.Background())
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, v)
+
+	type result struct {
+		value int
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		v, err := r.DequeueWait(context.Background())
+		done <- result{v, err}
+	}()
+
+	<-clock.waiting
+	select {
+	case <-done:
+		suite.Fail("returned before the token")
+	default:
+	}
+	clock.Advance(100 * time.Millisecond)
+	res := <-done
+	suite.Require().NoError(res.err)
+	suite.Require().Equal(1, res.value)
+}
+
+func (suite *RateLimitedTestSuite) TestDequeueWaitCancel() {
+	r, clock := suite.newFilled(1, 1, 3)
+	r.Dequeue()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := r.DequeueWait(ctx)
+		done <- err
+	}()
+	<-clock.waiting
+	cancel()
+	suite.Require().ErrorIs(<-done, context.Canceled)
+	suite.Require().Equal(2, r.Len())
+}
+
+func (suite *RateLimitedTestSuite) TestSetRate() {
+	r, clock := suite.newFilled(1, 5, 10)
+	rate, burst := r.Rate()
+	suite.Require().Equal(1.0, rate)
+	suite.Require().Equal(5, burst)
+
+	r.SetRate(100, 2)
+	// collected tokens are cut by the new burst
+	for i := 0; i < 2; i++ {
+		_, exists := r.Dequeue()
+		suite.Require().True(exists)
+	}
+	_, exists := r.Dequeue()
+	suite.Require().False(exists)
+
+	clock.Advance(10 * time.Millisecond)
+	_, exists = r.Dequeue()
+	suite.Require().True(exists)
+}
@@ -0,0 +1,196 @@
+package queue
+
+import (
+	"iter"
+
+	"github.com/HoskeOwl/ggstruct/list"
+	"github.com/HoskeOwl/ggstruct/set"
+)
+
+// UniqueQueue is a FIFO queue which holds every value only once.
+// Contains and Delete are O(1): deleted values stay in the list as stale copies
+// and are skipped on Dequeue. The list is compacted when stale copies outnumber pending values.
+// The zero value is an empty queue ready to use.
+type UniqueQueue[QT comparable] struct {
+	data  list.List[QT]
+	index set.Set[QT]
+	// number of stale copies of the value in the list, they are always before the pending one
+	stale      map[QT]int
+	staleLen   int
+	limit      int
+	moveToBack bool
+}
+
+// NewUnique returns new deduplicating queue instance.
+// Enqueue of a pending value is ignored, use WithMoveToBack to move it to the back instead.
+func NewUnique[T comparable]() *UniqueQueue[T] {
+	return &UniqueQueue[T]{}
+}
+
+// WithLimit sets the maximum number of elements in the queue and returns pointer to itself.
+// limit=0 mens no limits.
+func (q *UniqueQueue[QT]) WithLimit(limit int) *UniqueQueue[QT] {
+	q.limit = limit
+	return q
+}
+
+// Limit Returns current limit value.
+func (q *UniqueQueue[QT]) Limit() int {
+	if q.limit < 0 {
+		return 0
+	}
+	return q.limit
+}
+
+// WithMoveToBack sets whether Enqueue of a pending value moves it to the back of the queue
+// instead of ignoring it and returns pointer to itself.
+func (q *UniqueQueue[QT]) WithMoveToBack(move bool) *UniqueQueue[QT] {
+	q.moveToBack = move
+	return q
+}
+
+// Enqueue adds a new element to the queue. Returns 'false' if the element is already pending
+// (and not moved to the back) or the queue is full. Moving to the back doesn't check the limit.
+func (q *UniqueQueue[QT]) Enqueue(value QT) bool {
+	if q.index.Contains(value) {
+		if !q.moveToBack {
+			return false
+		}
+		q.markStale(value)
+		q.data.PushBack(value)
+		return true
+	}
+	if q.limit > 0 && q.index.Len()+1 > q.limit {
+		return false
+	}
+	q.index.Insert(value)
+	q.data.PushBack(value)
+	return true
+}
+
+// Dequeue get and remove the next element from the queue
+func (q *UniqueQueue[QT]) Dequeue() (value QT, exists bool) {
+	q.dropStale()
+	value, exists = q.data.PopFront()
+	if exists {
+		q.index.Remove(value)
+	}
+	return
+}
+
+// Peek returns the first item in the queue without removing it
+func (q *UniqueQueue[QT]) Peek() (value QT, exists bool) {
+	q.dropStale()
+	return q.data.Front()
+}
+
+// Delete remove an element from the queue. O(1).
+func (q *UniqueQueue[QT]) Delete(value QT) bool {
+	if !q.index.Contains(value) {
+		return false
+	}
+	q.index.Remove(value)
+	q.markStale(value)
+	return true
+}
+
+// Contains check if element is in the queue. O(1).
+func (q *UniqueQueue[QT]) Contains(value QT) bool {
+	return q.index.Contains(value)
+}
+
+// Len returns the number of items in the queue
+func (q *UniqueQueue[QT]) Len() int {
+	return q.index.Len()
+}
+
+// IsFull returns 'true' if elements count equal or greater than limit. With limit <= 0 always returns false.
+func (q *UniqueQueue[QT]) IsFull() bool {
+	if q.limit <= 0 {
+		return false
+	}
+	// >= because we can change limit in any time
+	return q.index.Len() >= q.limit
+}
+
+// IsEmpty returns 'true' if no elements on the queue.
+func (q *UniqueQueue[QT]) IsEmpty() bool {
+	return q.index.Len() == 0
+}
+
+// Clear removes all elements from the queue
+func (q *UniqueQueue[QT]) Clear() {
+	q.data = list.List[QT]{}
+	q.index = set.Set[QT]{}
+	q.stale = nil
+	q.staleLen = 0
+}
+
+// Clone returns a new queue with the same elements
+func (q *UniqueQueue[QT]) Clone() *UniqueQueue[QT] {
+	c := NewUnique[QT]().WithLimit(q.limit).WithMoveToBack(q.moveToBack)
+	for v := range q.pending() {
+		c.index.Insert(v)
+		c.data.PushBack(v)
+	}
+	return c
+}
+
+func (q *UniqueQueue[QT]) markStale(value QT) {
+	if q.stale == nil {
+		q.stale = make(map[QT]int)
+	}
+	q.stale[value]++
+	q.staleLen++
+	if q.staleLen > q.index.Len() {
+		q.compact()
+	}
+}
+
+// dropStale removes stale copies from the front of the list.
+func (q *UniqueQueue[QT]) dropStale() {
+	for q.staleLen > 0 {
+		v, exists := q.data.Front()
+		if !exists || q.stale[v] == 0 {
+			return
+		}
+		q.data.PopFront()
+		q.unmarkStale(v)
+	}
+}
+
+func (q *UniqueQueue[QT]) unmarkStale(value QT) {
+	q.staleLen--
+	if q.stale[value] == 1 {
+		delete(q.stale, value)
+	} else {
+		q.stale[value]--
+	}
+}
+
+// pending returns values without stale copies in the queue order.
+func (q *UniqueQueue[QT]) pending() iter.Seq[QT] {
+	return func(yield func(QT) bool) {
+		skip := make(map[QT]int, len(q.stale))
+		for v := range q.data.Seq() {
+			if skip[v] < q.stale[v] {
+				skip[v]++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// compact rebuilds the list without stale copies. O(N)
+func (q *UniqueQueue[QT]) compact() {
+	var data list.List[QT]
+	for v := range q.pending() {
+		data.PushBack(v)
+	}
+	q.data = data
+	q.stale = nil
+	q.staleLen = 0
+}
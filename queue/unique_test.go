@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"github.com/HoskeOwl/ggstruct/list"
+	"github.com/stretchr/testify/suite"
+	"slices"
+	"testing"
+)
+
+type UniqueQueueTestSuite struct {
+	suite.Suite
+}
+
+func TestRunUniqueQueueSuite(t *testing.T) {
+	suite.Run(t, new(UniqueQueueTestSuite))
+}
+
+func (suite *UniqueQueueTestSuite) drain(q *UniqueQueue[int]) []int {
+	var res []int
+	for {
+		v, exists := q.Dequeue()
+		if !exists {
+			return res
+		}
+		res = append(res, v)
+	}
+}
+
+func (suite *UniqueQueueTestSuite) TestEnqueueIgnore() {
+	q := NewUnique[int]()
+	suite.Require().True(q.Enqueue(1))
+	suite.Require().True(q.Enqueue(2))
+	suite.Require().False(q.Enqueue(1))
+	suite.Require().True(q.Enqueue(3))
+	suite.Require().Equal(3, q.Len())
+	suite.Require().True(q.Contains(1))
+	suite.Require().False(q.Contains(4))
+	suite.Require().Equal([]int{1, 2, 3}, suite.drain(q))
+	suite.Require().True(q.IsEmpty())
+
+	// dequeued value can be added again
+	suite.Require().True(q.Enqueue(1))
+	suite.Require().Equal([]int{1}, suite.drain(q))
+}
+
+func (suite *UniqueQueueTestSuite) TestEnqueueMoveToBack() {
+	q := NewUnique[int]().WithMoveToBack(true)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+	suite.Require().True(q.Enqueue(1))
+	suite.Require().True(q.Enqueue(2))
+	suite.Require().Equal(3, q.Len())
+
+	v, exists := q.Peek()
+	suite.Require().True(exists)
+	suite.Require().Equal(3, v)
+	suite.Require().Equal([]int{3, 1, 2}, suite.drain(q))
+}
+
+func (suite *UniqueQueueTestSuite) TestDelete() {
+	q := NewUnique[int]()
+	for i := 1; i <= 5; i++ {
+		q.Enqueue(i)
+	}
+	suite.Require().True(q.Delete(1))
+	suite.Require().True(q.Delete(3))
+	suite.Require().False(q.Delete(3))
+	suite.Require().False(q.Delete(10))
+	suite.Require().False(q.Contains(3))
+	suite.Require().Equal(3, q.Len())
+
+	// deleted value goes to the back when added again
+	suite.Require().True(q.Enqueue(1))
+	suite.Require().Equal([]int{2, 4, 5, 1}, suite.drain(q))
+}
+
+func (suite *UniqueQueueTestSuite) TestCompact() {
+	q := NewUnique[int]()
+	for i := 0; i < 10; i++ {
+		q.Enqueue(i)
+	}
+	for i := 0; i < 8; i++ {
+		q.Delete(i)
+	}
+	// list is compacted after stale copies outnumber pending values
+	suite.Require().Less(q.data.Len(), 10)
+	suite.Require().True(q.data.Len() >= q.Len())
+	suite.Require().Equal([]int{8, 9}, suite.drain(q))
+	suite.Require().Equal(0, q.data.Len())
+}
+
+func (suite *UniqueQueueTestSuite) TestLimit() {
+	q := NewUnique[int]().WithLimit(2).WithMoveToBack(true)
+	suite.Require().Equal(2, q.Limit())
+	suite.Require().True(q.Enqueue(1))
+	suite.Require().True(q.Enqueue(2))
+	suite.Require().True(q.IsFull())
+	suite.Require().False(q.Enqueue(3))
+	// moving doesn't change the size
+	suite.Require().True(q.Enqueue(1))
+	q.Delete(2)
+	suite.Require().False(q.IsFull())
+	suite.Require().True(q.Enqueue(3))
+	suite.Require().Equal([]int{1, 3}, suite.drain(q))
+}
+
+func (suite *UniqueQueueTestSuite) TestClearClone() {
+	q := NewUnique[int]().WithLimit(10)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+	q.Delete(2)
+
+	c := q.Clone()
+	suite.Require().Equal(10, c.Limit())
+	suite.Require().True(c.data.Equal(list.New(1, 3)))
+	suite.Require().Equal([]int{1, 3}, slices.Collect(c.pending()))
+
+	q.Clear()
+	suite.Require().True(q.IsEmpty())
+	_, exists := q.Peek()
+	suite.Require().False(exists)
+	suite.Require().Equal([]int{1, 3}, suite.drain(c))
+}
@@ -0,0 +1,117 @@
+package set
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// PowerSet returns iterator over all subsets of the set, starting with the empty one.
+// Subsets are produced one by one, so a set of n elements costs O(n) memory, not O(2^n).
+// The counter is a slice of flags, not an integer, so there is no overflow for big sets
+// (though such loops will never finish without "break").
+// Changes of the set during iteration are not visible to the iterator.
+func (s *Set[ST]) PowerSet() iter.Seq[*Set[ST]] {
+	return powerSet(maps.Keys(s.hash), func(values []ST) *Set[ST] { return New(values...) })
+}
+
+// Combinations returns iterator over all subsets with exactly k elements.
+// Nothing is yielded if k < 0 or k > Len(); k == 0 yields one empty set.
+// Changes of the set during iteration are not visible to the iterator.
+func (s *Set[ST]) Combinations(k int) iter.Seq[*Set[ST]] {
+	return combinations(maps.Keys(s.hash), k, func(values []ST) *Set[ST] { return New(values...) })
+}
+
+// powerSet yields sets built from all subsets of elements. Elements are collected when the loop starts.
+// The slice passed to build is reused, build must copy it.
+func powerSet[T, S any](elements iter.Seq[T], build func([]T) S) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		values := slices.Collect(elements)
+		picked := make([]bool, len(values))
+		subset := make([]T, 0, len(values))
+		for {
+			subset = subset[:0]
+			for i, v := range values {
+				if picked[i] {
+					subset = append(subset, v)
+				}
+			}
+			if !yield(build(subset)) {
+				return
+			}
+			// binary increment
+			i := 0
+			for i < len(picked) && picked[i] {
+				picked[i] = false
+				i++
+			}
+			if i == len(picked) {
+				return
+			}
+			picked[i] = true
+		}
+	}
+}
+
+// combinations yields sets built from all subsets of k elements. Elements are collected when the loop starts.
+// The slice passed to build is reused, build must copy it.
+func combinations[T, S any](elements iter.Seq[T], k int, build func([]T) S) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		values := slices.Collect(elements)
+		n := len(values)
+		if k < 0 || k > n {
+			return
+		}
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+		subset := make([]T, k)
+		for {
+			for j, i := range idx {
+				subset[j] = values[i]
+			}
+			if !yield(build(subset)) {
+				return
+			}
+			// find the rightmost index which can be moved forward
+			i := k - 1
+			for i >= 0 && idx[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+}
+
+// PowerSet returns iterator over all subsets of the set, starting with the empty one (see Set.PowerSet).
+// Subsets use functions of the set.
+func (s *HashSet[T]) PowerSet() iter.Seq[*HashSet[T]] {
+	return powerSet(s.Seq(), func(values []T) *HashSet[T] { return NewHash(s.hash, s.equal, values...) })
+}
+
+// Combinations returns iterator over all subsets with exactly k elements (see Set.Combinations).
+// Subsets use functions of the set.
+func (s *HashSet[T]) Combinations(k int) iter.Seq[*HashSet[T]] {
+	return combinations(s.Seq(), k, func(values []T) *HashSet[T] { return NewHash(s.hash, s.equal, values...) })
+}
+
+// CartesianProduct returns iterator over all pairs (x, y) where x is from "a" and y is from "b".
+// Pairs are produced lazily without building the product.
+func CartesianProduct[A, B comparable](a *Set[A], b *Set[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for x := range a.hash {
+			for y := range b.hash {
+				if !yield(x, y) {
+					return
+				}
+			}
+		}
+	}
+}
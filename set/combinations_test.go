@@ -0,0 +1,94 @@
+package set
+
+import (
+	"slices"
+)
+
+// keyOf builds a comparable key of a small int set for uniqueness checks.
+func keyOf(s *Set[int]) string {
+	values := slices.Sorted(s.Seq())
+	key := make([]byte, len(values))
+	for i, v := range values {
+		key[i] = byte(v)
+	}
+	return string(key)
+}
+
+func (suite *setTestSuite) TestPowerSet() {
+	s := New(1, 2, 3, 4)
+	seen := map[string]bool{}
+	for subset := range s.PowerSet() {
+		suite.Require().True(subset.SubsetOf(s))
+		seen[keyOf(subset)] = true
+	}
+	suite.Require().Len(seen, 16)
+
+	count := 0
+	for subset := range New[int]().PowerSet() {
+		suite.Require().Equal(0, subset.Len())
+		count++
+	}
+	suite.Require().Equal(1, count)
+
+	// a power set of 100 elements is never built in memory
+	big := New[int]()
+	for i := 0; i < 100; i++ {
+		big.Insert(i)
+	}
+	count = 0
+	for range big.PowerSet() {
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	suite.Require().Equal(5, count)
+}
+
+func (suite *setTestSuite) TestCombinations() {
+	s := New(1, 2, 3, 4, 5)
+	for k, expected := range []int{1, 5, 10, 10, 5, 1} {
+		seen := map[string]bool{}
+		for subset := range s.Combinations(k) {
+			suite.Require().Equal(k, subset.Len())
+			suite.Require().True(subset.SubsetOf(s))
+			seen[keyOf(subset)] = true
+		}
+		suite.Require().Len(seen, expected, "k=%d", k)
+	}
+	suite.Require().Empty(slices.Collect(s.Combinations(6)))
+	suite.Require().Empty(slices.Collect(s.Combinations(-1)))
+
+	count := 0
+	for range s.Combinations(2) {
+		count++
+		break
+	}
+	suite.Require().Equal(1, count)
+}
+
+func (suite *setTestSuite) TestCartesianProduct() {
+	a := New(1, 2)
+	b := New("x", "y", "z")
+	seen := map[string]bool{}
+	for x, y := range CartesianProduct(a, b) {
+		seen[string(rune('0'+x))+y] = true
+	}
+	suite.Require().Equal(map[string]bool{
+		"1x": true, "1y": true, "1z": true,
+		"2x": true, "2y": true, "2z": true,
+	}, seen)
+
+	count := 0
+	for range CartesianProduct(a, b) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	suite.Require().Equal(2, count)
+
+	for range CartesianProduct(a, New[string]()) {
+		suite.Fail("product with empty set is not empty")
+	}
+}
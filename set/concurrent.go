@@ -0,0 +1,195 @@
+package set
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+type concurrentShard[T comparable] struct {
+	mu   sync.RWMutex
+	hash map[T]empty
+	// keeps neighbour shards in different cache lines
+	_ [64 - 32]byte
+}
+
+// ConcurrentSet is a set safe for concurrent use. Elements are spread over shards by hash,
+// every shard has its own lock, so goroutines working with different shards don't wait for each other.
+// The zero value is an empty set ready to use.
+type ConcurrentSet[T comparable] struct {
+	once   sync.Once
+	seed   maphash.Seed
+	shards []concurrentShard[T]
+	mask   uint64
+}
+
+// NewConcurrent creates a new concurrent set with the default number of shards.
+func NewConcurrent[T comparable](initial ...T) *ConcurrentSet[T] {
+	s := &ConcurrentSet[T]{}
+	s.Insert(initial...)
+	return s
+}
+
+// ConcurrentFromSet creates a new concurrent set with elements of "set".
+func ConcurrentFromSet[T comparable](set *Set[T]) *ConcurrentSet[T] {
+	s := &ConcurrentSet[T]{}
+	for v := range set.hash {
+		s.Insert(v)
+	}
+	return s
+}
+
+// defaultShards returns 4 shards per processor rounded up to a power of two.
+func defaultShards() int {
+	return 1 << bits.Len(uint(4*runtime.GOMAXPROCS(0)-1))
+}
+
+// WithShards sets the number of shards (rounded up to a power of two, at least 1),
+// moves existing elements and returns pointer to itself.
+// It must be called before the set is shared between goroutines.
+func (s *ConcurrentSet[T]) WithShards(shards int) *ConcurrentSet[T] {
+	if shards < 1 {
+		shards = 1
+	}
+	shards = 1 << bits.Len(uint(shards-1))
+	old := s.shards
+	s.setup(shards)
+	for i := range old {
+		for v := range old[i].hash {
+			s.Insert(v)
+		}
+	}
+	return s
+}
+
+// Shards returns the number of shards.
+func (s *ConcurrentSet[T]) Shards() int {
+	s.init()
+	return len(s.shards)
+}
+
+func (s *ConcurrentSet[T]) setup(shards int) {
+	s.seed = maphash.MakeSeed()
+	s.shards = make([]concurrentShard[T], shards)
+	for i := range s.shards {
+		s.shards[i].hash = make(map[T]empty)
+	}
+	s.mask = uint64(shards - 1)
+}
+
+func (s *ConcurrentSet[T]) init() {
+	s.once.Do(func() {
+		if s.shards == nil {
+			s.setup(defaultShards())
+		}
+	})
+}
+
+func (s *ConcurrentSet[T]) shard(value T) *concurrentShard[T] {
+	s.init()
+	return &s.shards[maphash.Comparable(s.seed, value)&s.mask]
+}
+
+// Insert adds elements to the set.
+func (s *ConcurrentSet[T]) Insert(elements ...T) {
+	for _, e := range elements {
+		s.InsertIfAbsent(e)
+	}
+}
+
+// InsertIfAbsent adds the element and returns 'true' if it was not in the set.
+func (s *ConcurrentSet[T]) InsertIfAbsent(element T) bool {
+	sh := s.shard(element)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if _, exists := sh.hash[element]; exists {
+		return false
+	}
+	sh.hash[element] = empty{}
+	return true
+}
+
+// Remove removes the element and returns 'true' if it was in the set.
+func (s *ConcurrentSet[T]) Remove(element T) bool {
+	sh := s.shard(element)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if _, exists := sh.hash[element]; !exists {
+		return false
+	}
+	delete(sh.hash, element)
+	return true
+}
+
+// Contains checks is element in the set or not.
+func (s *ConcurrentSet[T]) Contains(element T) bool {
+	sh := s.shard(element)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	_, exists := sh.hash[element]
+	return exists
+}
+
+// Len returns number of items in the set. Shards are counted one by one,
+// so the result is approximate if the set is changed at the same time.
+func (s *ConcurrentSet[T]) Len() int {
+	s.init()
+	n := 0
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.RLock()
+		n += len(sh.hash)
+		sh.mu.RUnlock()
+	}
+	return n
+}
+
+// Clear removes all elements.
+func (s *ConcurrentSet[T]) Clear() {
+	s.init()
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		sh.hash = make(map[T]empty)
+		sh.mu.Unlock()
+	}
+}
+
+// snapshot copies elements shard by shard.
+func (s *ConcurrentSet[T]) snapshot() []T {
+	s.init()
+	values := make([]T, 0, s.Len())
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.RLock()
+		for v := range sh.hash {
+			values = append(values, v)
+		}
+		sh.mu.RUnlock()
+	}
+	return values
+}
+
+// Seq returns value-iterator over a snapshot taken when the loop starts.
+// No locks are held during the loop, so the set can be changed inside it.
+func (s *ConcurrentSet[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToSet returns a snapshot of elements as a Set.
+func (s *ConcurrentSet[T]) ToSet() *Set[T] {
+	values := s.snapshot()
+	res := &Set[T]{hash: make(map[T]empty, len(values))}
+	for _, v := range values {
+		res.hash[v] = empty{}
+	}
+	return res
+}
@@ -0,0 +1,140 @@
+package set
+
+import (
+	"github.com/stretchr/testify/suite"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type concurrentSetTestSuite struct {
+	suite.Suite
+}
+
+func TestConcurrentSet(t *testing.T) {
+	suite.Run(t, new(concurrentSetTestSuite))
+}
+
+func (suite *concurrentSetTestSuite) TestBasic() {
+	var s ConcurrentSet[int]
+	suite.Require().Equal(0, s.Len())
+	suite.Require().False(s.Contains(1))
+	suite.Require().False(s.Remove(1))
+	suite.Require().Empty(slices.Collect(s.Seq()))
+
+	suite.Require().True(s.InsertIfAbsent(1))
+	suite.Require().False(s.InsertIfAbsent(1))
+	s.Insert(2, 3, 2)
+	suite.Require().Equal(3, s.Len())
+	suite.Require().True(s.Contains(2))
+	suite.Require().Equal([]int{1, 2, 3}, slices.Sorted(s.Seq()))
+
+	suite.Require().True(s.Remove(2))
+	suite.Require().False(s.Contains(2))
+	suite.Require().Equal(2, s.Len())
+
+	// changes inside the loop don't deadlock
+	for v := range s.Seq() {
+		s.Remove(v)
+		s.Insert(v + 100)
+	}
+	suite.Require().Equal([]int{101, 103}, slices.Sorted(s.Seq()))
+
+	s.Clear()
+	suite.Require().Equal(0, s.Len())
+}
+
+func (suite *concurrentSetTestSuite) TestShards() {
+	suite.Require().Equal(1, NewConcurrent[int]().WithShards(0).Shards())
+	suite.Require().Equal(8, NewConcurrent[int]().WithShards(5).Shards())
+	suite.Require().Equal(8, NewConcurrent[int]().WithShards(8).Shards())
+	shards := NewConcurrent[int]().Shards()
+	suite.Require().Equal(0, shards&(shards-1), "not a power of two")
+
+	s := NewConcurrent(1, 2, 3, 4, 5).WithShards(2)
+	suite.Require().Equal(2, s.Shards())
+	suite.Require().Equal([]int{1, 2, 3, 4, 5}, slices.Sorted(s.Seq()))
+}
+
+func (suite *concurrentSetTestSuite) TestConvert() {
+	src := New(1, 2, 3)
+	s := ConcurrentFromSet(src)
+	suite.Require().Equal(3, s.Len())
+	res := s.ToSet()
+	suite.Require().True(res.Equal(src))
+	res.Insert(4)
+	suite.Require().False(s.Contains(4))
+	suite.Require().Equal(0, ConcurrentFromSet(&Set[int]{}).Len())
+}
+
+func (suite *concurrentSetTestSuite) TestStress() {
+	const (
+		workers = 8
+		values  = 1000
+	)
+	var s ConcurrentSet[int]
+	var inserted, removed atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < values; i++ {
+				// every value is inserted by all workers, only one of them wins
+				if s.InsertIfAbsent(i) {
+					inserted.Add(1)
+				}
+				s.Contains(i)
+				if i%(w+2) == 0 && s.Remove(i) {
+					removed.Add(1)
+				}
+				if i%100 == 0 {
+					s.Len()
+					for range s.Seq() {
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	suite.Require().Equal(int(inserted.Load()-removed.Load()), s.Len())
+	suite.Require().Equal(s.Len(), len(slices.Collect(s.Seq())))
+	suite.Require().Equal(s.Len(), s.ToSet().Len())
+}
+
+func BenchmarkConcurrentSet(b *testing.B) {
+	s := NewConcurrent[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%8 == 0 {
+				s.Insert(i % 4096)
+			} else {
+				s.Contains(i % 4096)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMutexSet(b *testing.B) {
+	var mu sync.RWMutex
+	s := New[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%8 == 0 {
+				mu.Lock()
+				s.Insert(i % 4096)
+				mu.Unlock()
+			} else {
+				mu.RLock()
+				s.Contains(i % 4096)
+				mu.RUnlock()
+			}
+			i++
+		}
+	})
+}
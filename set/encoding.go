@@ -0,0 +1,287 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrDuplicate    = errors.New("duplicate element")
+	ErrSeparator    = errors.New("element contains separator")
+	ErrEmptyElement = errors.New("empty element can't be encoded as text")
+	ErrNoHash       = errors.New("set has no hash functions, create it with NewHash")
+)
+
+// TextSeparator separates elements in TextSet.MarshalText/UnmarshalText.
+const TextSeparator = ","
+
+// sortByKey sorts values by keys which are calculated once for every element.
+func sortByKey[ST any, K cmp.Ordered](values []ST, key func(reflect.Value) K) {
+	type pair struct {
+		key   K
+		value ST
+	}
+	pairs := make([]pair, len(values))
+	for i, v := range values {
+		pairs[i] = pair{key(reflect.ValueOf(v)), v}
+	}
+	slices.SortFunc(pairs, func(a, b pair) int { return cmp.Compare(a.key, b.key) })
+	for i := range pairs {
+		values[i] = pairs[i].value
+	}
+}
+
+// values returns elements sorted if ST is based on an ordered type (see cmp.Ordered), otherwise in random order.
+func (s *Set[ST]) values() []ST {
+	return sortedValues(maps.Keys(s.hash))
+}
+
+// sortedValues collects elements and sorts them if T is based on an ordered type.
+func sortedValues[T any](elements iter.Seq[T]) []T {
+	values := slices.Collect(elements)
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sortByKey(values, reflect.Value.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sortByKey(values, reflect.Value.Uint)
+	case reflect.Float32, reflect.Float64:
+		sortByKey(values, reflect.Value.Float)
+	case reflect.String:
+		sortByKey(values, reflect.Value.String)
+	}
+	return values
+}
+
+// fill replaces elements by values. Repeated values are merged or, with reject, fail with ErrDuplicate.
+func (s *Set[ST]) fill(values []ST, reject bool) error {
+	hash := make(map[ST]empty, len(values))
+	for _, v := range values {
+		if _, exists := hash[v]; exists && reject {
+			return fmt.Errorf("%w: %v", ErrDuplicate, v)
+		}
+		hash[v] = empty{}
+	}
+	s.hash = hash
+	return nil
+}
+
+// MarshalJSON encodes the set as a JSON array. Elements are sorted if the type is ordered.
+func (s *Set[ST]) MarshalJSON() ([]byte, error) {
+	values := s.values()
+	if values == nil {
+		values = []ST{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON replaces elements by the JSON array. Repeated elements are merged. JSON null does nothing.
+func (s *Set[ST]) UnmarshalJSON(data []byte) error {
+	return s.unmarshalJSON(data, false)
+}
+
+func (s *Set[ST]) unmarshalJSON(data []byte, reject bool) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var values []ST
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	return s.fill(values, reject)
+}
+
+// GobEncode encodes elements as a gob slice. Elements are sorted if the type is ordered.
+func (s *Set[ST]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.values()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces elements by the result of GobEncode.
+func (s *Set[ST]) GobDecode(data []byte) error {
+	return s.gobDecode(data, false)
+}
+
+func (s *Set[ST]) gobDecode(data []byte, reject bool) error {
+	var values []ST
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+	return s.fill(values, reject)
+}
+
+// MarshalYAML encodes the set as a YAML sequence. Elements are sorted if the type is ordered.
+func (s *Set[ST]) MarshalYAML() (any, error) {
+	values := s.values()
+	if values == nil {
+		values = []ST{}
+	}
+	return values, nil
+}
+
+// UnmarshalYAML replaces elements by the YAML sequence. Repeated elements are merged.
+// It uses the callback form of the interface, which is supported by gopkg.in/yaml.v2 and v3.
+func (s *Set[ST]) UnmarshalYAML(unmarshal func(any) error) error {
+	return s.unmarshalYAML(unmarshal, false)
+}
+
+func (s *Set[ST]) unmarshalYAML(unmarshal func(any) error, reject bool) error {
+	var values []ST
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	return s.fill(values, reject)
+}
+
+// StrictSet is a set which rejects repeated elements in decoding (JSON, gob, YAML) with ErrDuplicate,
+// for example to catch typos in config files. On error the set is not changed.
+// Only decoding differs from Set: results of operations on the embedded Set are usual sets.
+// The zero value is an empty set ready to use.
+type StrictSet[ST comparable] struct {
+	Set[ST]
+}
+
+// NewStrict creates a new set which rejects repeated elements in decoding.
+func NewStrict[T comparable](initial ...T) *StrictSet[T] {
+	return &StrictSet[T]{Set: *New(initial...)}
+}
+
+// UnmarshalJSON replaces elements by the JSON array. Returns ErrDuplicate for repeated elements.
+func (s *StrictSet[ST]) UnmarshalJSON(data []byte) error {
+	return s.unmarshalJSON(data, true)
+}
+
+// GobDecode replaces elements by the result of GobEncode. Returns ErrDuplicate for repeated elements.
+func (s *StrictSet[ST]) GobDecode(data []byte) error {
+	return s.gobDecode(data, true)
+}
+
+// UnmarshalYAML replaces elements by the YAML sequence. Returns ErrDuplicate for repeated elements.
+func (s *StrictSet[ST]) UnmarshalYAML(unmarshal func(any) error) error {
+	return s.unmarshalYAML(unmarshal, true)
+}
+
+// setAll replaces elements by values. Equal values are merged.
+func (s *HashSet[T]) setAll(values []T) error {
+	if s.hash == nil {
+		return ErrNoHash
+	}
+	s.Clear()
+	s.Insert(values...)
+	return nil
+}
+
+// MarshalJSON encodes the set as a JSON array. Elements are sorted if the type is ordered.
+func (s *HashSet[T]) MarshalJSON() ([]byte, error) {
+	values := sortedValues(s.Seq())
+	if values == nil {
+		values = []T{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON replaces elements by the JSON array. JSON null does nothing.
+// The set must be created by NewHash (decoding can't create functions), otherwise returns ErrNoHash.
+func (s *HashSet[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	return s.setAll(values)
+}
+
+// GobEncode encodes elements as a gob slice. Elements are sorted if the type is ordered.
+func (s *HashSet[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(sortedValues(s.Seq())); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces elements by the result of GobEncode. The set must be created by NewHash.
+func (s *HashSet[T]) GobDecode(data []byte) error {
+	var values []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+	return s.setAll(values)
+}
+
+// MarshalYAML encodes the set as a YAML sequence. Elements are sorted if the type is ordered.
+func (s *HashSet[T]) MarshalYAML() (any, error) {
+	values := sortedValues(s.Seq())
+	if values == nil {
+		values = []T{}
+	}
+	return values, nil
+}
+
+// UnmarshalYAML replaces elements by the YAML sequence. The set must be created by NewHash.
+func (s *HashSet[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var values []T
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	return s.setAll(values)
+}
+
+// TextSet is a set of strings which can be encoded as text (encoding.TextMarshaler),
+// for example for flags, environment variables or map keys. Other encodings are the same as for Set.
+// The zero value is an empty set ready to use.
+type TextSet[T ~string] struct {
+	Set[T]
+}
+
+// NewText creates a new text-encodable set of strings.
+func NewText[T ~string](initial ...T) *TextSet[T] {
+	return &TextSet[T]{Set: *New(initial...)}
+}
+
+// MarshalText encodes sorted elements joined by TextSeparator.
+// Returns ErrSeparator if an element contains the separator and ErrEmptyElement for an empty string,
+// because both can't be decoded back.
+func (s *TextSet[T]) MarshalText() ([]byte, error) {
+	values := slices.Sorted(maps.Keys(s.hash))
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = string(v)
+		if parts[i] == "" {
+			return nil, ErrEmptyElement
+		}
+		if strings.Contains(parts[i], TextSeparator) {
+			return nil, fmt.Errorf("%w: %q", ErrSeparator, parts[i])
+		}
+	}
+	return []byte(strings.Join(parts, TextSeparator)), nil
+}
+
+// UnmarshalText replaces elements by the result of MarshalText. Repeated elements are merged. Empty text is an empty set.
+func (s *TextSet[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return s.fill(nil, false)
+	}
+	parts := strings.Split(string(text), TextSeparator)
+	values := make([]T, len(parts))
+	for i, p := range parts {
+		if p == "" {
+			return ErrEmptyElement
+		}
+		values[i] = T(p)
+	}
+	return s.fill(values, false)
+}
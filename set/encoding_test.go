@@ -0,0 +1,169 @@
+package set
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"gopkg.in/yaml.v3"
+)
+
+type taggedConfig struct {
+	Name string       `json:"name"`
+	Tags *Set[string] `json:"tags"`
+	IDs  *Set[int]    `json:"ids"`
+}
+
+func (suite *setTestSuite) TestJSON() {
+	data, err := json.Marshal(New(3, 1, 20, 2))
+	suite.Require().NoError(err)
+	suite.Require().Equal(`[1,2,3,20]`, string(data))
+
+	data, err = json.Marshal(New[string]())
+	suite.Require().NoError(err)
+	suite.Require().Equal(`[]`, string(data))
+
+	var zero Set[int]
+	data, err = json.Marshal(&zero)
+	suite.Require().NoError(err)
+	suite.Require().Equal(`[]`, string(data))
+
+	cfg := taggedConfig{Name: "svc", Tags: New("b", "a", "c"), IDs: New(2, 1)}
+	data, err = json.Marshal(cfg)
+	suite.Require().NoError(err)
+	suite.Require().Equal(`{"name":"svc","tags":["a","b","c"],"ids":[1,2]}`, string(data))
+
+	var decoded taggedConfig
+	suite.Require().NoError(json.Unmarshal(data, &decoded))
+	suite.Require().True(decoded.Tags.Equal(cfg.Tags))
+	suite.Require().True(decoded.IDs.Equal(cfg.IDs))
+
+	// replaces old elements
+	s := New(7)
+	suite.Require().NoError(json.Unmarshal([]byte(`[1,2,2]`), s))
+	suite.Require().Equal(newTestMap(1, 2), s.hash)
+
+	suite.Require().NoError(json.Unmarshal([]byte(`null`), s))
+	suite.Require().Equal(newTestMap(1, 2), s.hash)
+
+	suite.Require().Error(json.Unmarshal([]byte(`{"a":1}`), s))
+	suite.Require().Equal(newTestMap(1, 2), s.hash)
+}
+
+func (suite *setTestSuite) TestJSONDuplicates() {
+	// Set merges repeated elements
+	merged := New[int]()
+	suite.Require().NoError(json.Unmarshal([]byte(`[1,2,1]`), merged))
+	suite.Require().Equal(newTestMap(1, 2), merged.hash)
+
+	s := NewStrict(5)
+	err := json.Unmarshal([]byte(`[1,2,1]`), s)
+	suite.Require().True(errors.Is(err, ErrDuplicate))
+	suite.Require().Equal(newTestMap(5), s.hash)
+	suite.Require().NoError(json.Unmarshal([]byte(`[1,2]`), s))
+	suite.Require().Equal(newTestMap(1, 2), s.hash)
+	data, err := json.Marshal(s)
+	suite.Require().NoError(err)
+	suite.Require().Equal(`[1,2]`, string(data))
+
+	// works through a struct field, the zero value is ready to use
+	var cfg struct {
+		Tags StrictSet[string] `json:"tags"`
+	}
+	err = json.Unmarshal([]byte(`{"tags":["a","a"]}`), &cfg)
+	suite.Require().True(errors.Is(err, ErrDuplicate))
+	suite.Require().NoError(json.Unmarshal([]byte(`{"tags":["a","b"]}`), &cfg))
+	suite.Require().True(cfg.Tags.Equal(New("a", "b")))
+}
+
+func (suite *setTestSuite) TestGob() {
+	var buf bytes.Buffer
+	cfg := taggedConfig{Name: "svc", Tags: New("b", "a"), IDs: New(3, 1)}
+	suite.Require().NoError(gob.NewEncoder(&buf).Encode(cfg))
+
+	var decoded taggedConfig
+	suite.Require().NoError(gob.NewDecoder(&buf).Decode(&decoded))
+	suite.Require().Equal("svc", decoded.Name)
+	suite.Require().True(decoded.Tags.Equal(cfg.Tags))
+	suite.Require().True(decoded.IDs.Equal(cfg.IDs))
+
+	first, err := New(5, 4, 3, 2, 1).GobEncode()
+	suite.Require().NoError(err)
+	second, err := New(1, 2, 3, 4, 5).GobEncode()
+	suite.Require().NoError(err)
+	suite.Require().Equal(first, second)
+
+	s := NewStrict[int]()
+	data, err := New(1, 2).GobEncode()
+	suite.Require().NoError(err)
+	suite.Require().NoError(s.GobDecode(data))
+	suite.Require().Equal(newTestMap(1, 2), s.hash)
+	suite.Require().Error(s.GobDecode([]byte("garbage")))
+
+	buf.Reset()
+	suite.Require().NoError(gob.NewEncoder(&buf).Encode([]int{1, 1}))
+	suite.Require().ErrorIs(s.GobDecode(buf.Bytes()), ErrDuplicate)
+	suite.Require().NoError(New[int]().GobDecode(buf.Bytes()))
+}
+
+func (suite *setTestSuite) TestText() {
+	type name string
+	text, err := NewText[name]("b", "c", "a").MarshalText()
+	suite.Require().NoError(err)
+	suite.Require().Equal("a,b,c", string(text))
+
+	var s TextSet[name]
+	suite.Require().NoError(s.UnmarshalText([]byte("x,y,x")))
+	suite.Require().True(s.Equal(New[name]("x", "y")))
+	suite.Require().NoError(s.UnmarshalText(nil))
+	suite.Require().Equal(0, s.Len())
+
+	_, err = NewText("a,b").MarshalText()
+	suite.Require().ErrorIs(err, ErrSeparator)
+	_, err = NewText("").MarshalText()
+	suite.Require().ErrorIs(err, ErrEmptyElement)
+	suite.Require().ErrorIs(s.UnmarshalText([]byte("x,,y")), ErrEmptyElement)
+
+	// only TextSet is encoded as text, other encodings are the same as for Set
+	suite.Require().NotImplements((*encoding.TextMarshaler)(nil), New("a"))
+	suite.Require().Implements((*encoding.TextMarshaler)(nil), NewText("a"))
+	data, err := json.Marshal(NewText("b", "a"))
+	suite.Require().NoError(err)
+	suite.Require().Equal(`["a","b"]`, string(data))
+}
+
+func (suite *setTestSuite) TestYAML() {
+	type config struct {
+		IDs   *Set[int]        `yaml:"ids"`
+		Tags  *Set[string]     `yaml:"tags"`
+		Flags *TextSet[string] `yaml:"flags"`
+	}
+	cfg := config{IDs: New(3, 1, 2), Tags: New("b", "a"), Flags: NewText("y", "x")}
+	data, err := yaml.Marshal(cfg)
+	suite.Require().NoError(err)
+	suite.Require().Equal("ids:\n    - 1\n    - 2\n    - 3\ntags:\n    - a\n    - b\nflags:\n    - x\n    - \"y\"\n", string(data))
+
+	var decoded config
+	suite.Require().NoError(yaml.Unmarshal(data, &decoded))
+	suite.Require().True(decoded.IDs.Equal(cfg.IDs))
+	suite.Require().True(decoded.Tags.Equal(cfg.Tags))
+	suite.Require().True(decoded.Flags.Equal(&cfg.Flags.Set))
+
+	data, err = yaml.Marshal(config{IDs: New[int]()})
+	suite.Require().NoError(err)
+	suite.Require().Equal("ids: []\ntags: null\nflags: null\n", string(data))
+
+	s := NewStrict[int]()
+	suite.Require().ErrorIs(yaml.Unmarshal([]byte("[1, 1]"), s), ErrDuplicate)
+	suite.Require().Error(yaml.Unmarshal([]byte("a: 1"), s))
+	suite.Require().NoError(yaml.Unmarshal([]byte("[1, 1]"), New[int]()))
+}
+
+func (suite *setTestSuite) TestSortedValues() {
+	type id uint8
+	suite.Require().Equal([]id{1, 2, 200}, New[id](200, 2, 1).values())
+	suite.Require().Equal([]float64{-1.5, 0, 2}, New(2, -1.5, 0).values())
+	suite.Require().Equal([]int64{-5, 3, 10}, New[int64](10, -5, 3).values())
+	suite.Require().Len(New(struct{ a int }{1}, struct{ a int }{2}).values(), 2)
+}
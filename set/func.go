@@ -0,0 +1,94 @@
+package set
+
+import (
+	"iter"
+	"maps"
+)
+
+// FromSeq creates a new set with values of the iterator.
+func FromSeq[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := New[T]()
+	for v := range seq {
+		s.hash[v] = empty{}
+	}
+	return s
+}
+
+// Collect is the same as FromSeq, named like slices.Collect and maps.Collect.
+// For example, Collect(maps.Keys(m)) or Collect(slices.Values(list)).
+func Collect[T comparable](seq iter.Seq[T]) *Set[T] {
+	return FromSeq(seq)
+}
+
+// Filter returns a new set with elements for which keep returns 'true'.
+func Filter[T comparable](s *Set[T], keep func(T) bool) *Set[T] {
+	res := New[T]()
+	for v := range s.hash {
+		if keep(v) {
+			res.hash[v] = empty{}
+		}
+	}
+	return res
+}
+
+// Map returns a new set with results of f for all elements. Equal results are merged.
+func Map[T, U comparable](s *Set[T], f func(T) U) *Set[U] {
+	res := &Set[U]{hash: make(map[U]empty, s.Len())}
+	for v := range s.hash {
+		res.hash[f(v)] = empty{}
+	}
+	return res
+}
+
+// Partition splits elements into two new sets: for which pred returns 'true' and 'false'.
+func Partition[T comparable](s *Set[T], pred func(T) bool) (yes, no *Set[T]) {
+	yes, no = New[T](), New[T]()
+	for v := range s.hash {
+		if pred(v) {
+			yes.hash[v] = empty{}
+		} else {
+			no.hash[v] = empty{}
+		}
+	}
+	return
+}
+
+// Any checks that pred returns 'true' for at least one element. Returns false for an empty set.
+func Any[T comparable](s *Set[T], pred func(T) bool) bool {
+	for v := range s.hash {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// All checks that pred returns 'true' for every element. Returns true for an empty set.
+func All[T comparable](s *Set[T], pred func(T) bool) bool {
+	for v := range s.hash {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// GroupBy splits elements into new sets by the result of key.
+func GroupBy[T comparable, K comparable](s *Set[T], key func(T) K) map[K]*Set[T] {
+	res := make(map[K]*Set[T])
+	for v := range s.hash {
+		k := key(v)
+		group, exists := res[k]
+		if !exists {
+			group = New[T]()
+			res[k] = group
+		}
+		group.hash[v] = empty{}
+	}
+	return res
+}
+
+// RemoveFunc removes all elements for which del returns 'true'.
+func (s *Set[ST]) RemoveFunc(del func(ST) bool) {
+	maps.DeleteFunc(s.hash, func(k ST, _ empty) bool { return del(k) })
+}
@@ -0,0 +1,69 @@
+package set
+
+import (
+	"maps"
+	"slices"
+)
+
+func isEven(v int) bool {
+	return v%2 == 0
+}
+
+func (suite *setTestSuite) TestFromSeq() {
+	s := FromSeq(slices.Values([]int{1, 2, 2, 3}))
+	suite.Require().Equal(newTestMap(1, 2, 3), s.hash)
+	s = Collect(maps.Keys(map[int]string{4: "a", 5: "b"}))
+	suite.Require().Equal(newTestMap(4, 5), s.hash)
+	suite.Require().Equal(0, Collect(slices.Values([]int(nil))).Len())
+	suite.Require().True(s.Equal(Collect(s.Seq())))
+}
+
+func (suite *setTestSuite) TestFilterMap() {
+	s := New(1, 2, 3, 4)
+	suite.Require().Equal(newTestMap(2, 4), Filter(s, isEven).hash)
+	suite.Require().Equal(4, s.Len())
+
+	half := Map(s, func(v int) int { return v / 2 })
+	suite.Require().Equal(newTestMap(0, 1, 2), half.hash)
+	names := Map(s, func(v int) string { return string(rune('a' + v)) })
+	suite.Require().True(names.Equal(New("b", "c", "d", "e")))
+	suite.Require().Equal(0, Map(New[int](), isEven).Len())
+}
+
+func (suite *setTestSuite) TestPartition() {
+	yes, no := Partition(New(1, 2, 3, 4, 5), isEven)
+	suite.Require().Equal(newTestMap(2, 4), yes.hash)
+	suite.Require().Equal(newTestMap(1, 3, 5), no.hash)
+
+	yes, no = Partition(New[int](), isEven)
+	suite.Require().Equal(0, yes.Len())
+	suite.Require().Equal(0, no.Len())
+}
+
+func (suite *setTestSuite) TestAnyAll() {
+	suite.Require().True(Any(New(1, 2), isEven))
+	suite.Require().False(Any(New(1, 3), isEven))
+	suite.Require().False(Any(New[int](), isEven))
+	suite.Require().True(All(New(2, 4), isEven))
+	suite.Require().False(All(New(2, 3), isEven))
+	suite.Require().True(All(New[int](), isEven))
+}
+
+func (suite *setTestSuite) TestGroupBy() {
+	groups := GroupBy(New(1, 2, 3, 4, 5, 6), func(v int) int { return v % 3 })
+	suite.Require().Len(groups, 3)
+	suite.Require().Equal(newTestMap(3, 6), groups[0].hash)
+	suite.Require().Equal(newTestMap(1, 4), groups[1].hash)
+	suite.Require().Equal(newTestMap(2, 5), groups[2].hash)
+	suite.Require().Empty(GroupBy(New[int](), isEven))
+}
+
+func (suite *setTestSuite) TestRemoveFunc() {
+	s := New(1, 2, 3, 4)
+	s.RemoveFunc(isEven)
+	suite.Require().Equal(newTestMap(1, 3), s.hash)
+
+	var zero Set[int]
+	zero.RemoveFunc(isEven)
+	suite.Require().Equal(0, zero.Len())
+}
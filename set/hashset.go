@@ -0,0 +1,296 @@
+package set
+
+import (
+	"iter"
+	"slices"
+)
+
+// HashSet is an unordered set which uses user functions for hashing and comparing elements.
+// It can hold non-comparable values (slices, structs with slices) or treat different values
+// as equal (case-insensitive strings). Equal values must have equal hashes.
+// Binary operations use functions of the receiver.
+// The API is the same as of Set. The zero value has no functions and can't be used, create sets with NewHash.
+// Decoding (JSON, gob, YAML) can't create functions either, so decode into sets created by NewHash.
+type HashSet[T any] struct {
+	buckets map[uint64][]T
+	len     int
+	hash    func(T) uint64
+	equal   func(a, b T) bool
+}
+
+// NewHash creates a new set with the hash and equal functions.
+func NewHash[T any](hash func(T) uint64, equal func(a, b T) bool, initial ...T) *HashSet[T] {
+	s := &HashSet[T]{
+		buckets: make(map[uint64][]T),
+		hash:    hash,
+		equal:   equal,
+	}
+	s.Insert(initial...)
+	return s
+}
+
+// empty returns a new empty set with same functions.
+func (s *HashSet[T]) empty() *HashSet[T] {
+	return NewHash(s.hash, s.equal)
+}
+
+func (s *HashSet[T]) find(bucket []T, element T) int {
+	return slices.IndexFunc(bucket, func(v T) bool { return s.equal(v, element) })
+}
+
+// Seq returns value-iterator.
+func (s *HashSet[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, bucket := range s.buckets {
+			for _, v := range bucket {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Contains checks is element in the set or not.
+func (s *HashSet[T]) Contains(element T) bool {
+	return s.find(s.buckets[s.hash(element)], element) >= 0
+}
+
+// Insert adds elements to the set. If an equal element is in the set already, it is kept.
+func (s *HashSet[T]) Insert(elements ...T) {
+	for _, e := range elements {
+		h := s.hash(e)
+		bucket := s.buckets[h]
+		if s.find(bucket, e) >= 0 {
+			continue
+		}
+		s.buckets[h] = append(bucket, e)
+		s.len++
+	}
+}
+
+// Remove removes elements from the set. Missing elements do nothing.
+func (s *HashSet[T]) Remove(elements ...T) {
+	for _, e := range elements {
+		h := s.hash(e)
+		bucket := s.buckets[h]
+		idx := s.find(bucket, e)
+		if idx < 0 {
+			continue
+		}
+		if len(bucket) == 1 {
+			delete(s.buckets, h)
+		} else {
+			s.buckets[h] = slices.Delete(bucket, idx, idx+1)
+		}
+		s.len--
+	}
+}
+
+// Len returns number of items in the set.
+func (s *HashSet[T]) Len() int {
+	return s.len
+}
+
+// Clear removes all elements.
+func (s *HashSet[T]) Clear() {
+	s.buckets = make(map[uint64][]T)
+	s.len = 0
+}
+
+// Clone returns a new set with same elements.
+func (s *HashSet[T]) Clone() *HashSet[T] {
+	res := &HashSet[T]{
+		buckets: make(map[uint64][]T, len(s.buckets)),
+		len:     s.len,
+		hash:    s.hash,
+		equal:   s.equal,
+	}
+	for h, bucket := range s.buckets {
+		res.buckets[h] = slices.Clone(bucket)
+	}
+	return res
+}
+
+// Intersection returns elements which are in both sets.
+func (s *HashSet[T]) Intersection(other *HashSet[T]) *HashSet[T] {
+	res := s.empty()
+	for v := range s.Seq() {
+		if other.Contains(v) {
+			res.Insert(v)
+		}
+	}
+	return res
+}
+
+// SymmetricDifference returns unique elements for both sets.
+func (s *HashSet[T]) SymmetricDifference(other *HashSet[T]) (*HashSet[T], *HashSet[T]) {
+	return s.Difference(other), other.Difference(s)
+}
+
+// Difference returns unique elements for that set.
+func (s *HashSet[T]) Difference(other *HashSet[T]) *HashSet[T] {
+	res := s.empty()
+	for v := range s.Seq() {
+		if !other.Contains(v) {
+			res.Insert(v)
+		}
+	}
+	return res
+}
+
+// Union returns all elements of both sets.
+func (s *HashSet[T]) Union(other *HashSet[T]) *HashSet[T] {
+	res := s.Clone()
+	res.UnionWith(other)
+	return res
+}
+
+// UnionWith adds all elements of "other" to this set.
+func (s *HashSet[T]) UnionWith(other *HashSet[T]) {
+	for v := range other.Seq() {
+		s.Insert(v)
+	}
+}
+
+// InsertSet same as UnionWith.
+func (s *HashSet[T]) InsertSet(other *HashSet[T]) {
+	s.UnionWith(other)
+}
+
+// IntersectWith keeps only elements which are in "other".
+func (s *HashSet[T]) IntersectWith(other *HashSet[T]) {
+	s.RemoveFunc(func(v T) bool { return !other.Contains(v) })
+}
+
+// RetainAll same as IntersectWith.
+func (s *HashSet[T]) RetainAll(other *HashSet[T]) {
+	s.IntersectWith(other)
+}
+
+// DifferenceWith removes all elements of "other" from this set.
+func (s *HashSet[T]) DifferenceWith(other *HashSet[T]) {
+	s.RemoveFunc(other.Contains)
+}
+
+// RemoveAll same as DifferenceWith.
+func (s *HashSet[T]) RemoveAll(other *HashSet[T]) {
+	s.DifferenceWith(other)
+}
+
+// SymmetricDifferenceSet returns elements which are in only one of the sets.
+func (s *HashSet[T]) SymmetricDifferenceSet(other *HashSet[T]) *HashSet[T] {
+	res := s.Difference(other)
+	for v := range other.Seq() {
+		if !s.Contains(v) {
+			res.Insert(v)
+		}
+	}
+	return res
+}
+
+// RemoveFunc removes all elements for which del returns 'true'.
+func (s *HashSet[T]) RemoveFunc(del func(T) bool) {
+	for h, bucket := range s.buckets {
+		n := len(bucket)
+		bucket = slices.DeleteFunc(bucket, del)
+		s.len -= n - len(bucket)
+		if len(bucket) == 0 {
+			delete(s.buckets, h)
+		} else {
+			s.buckets[h] = bucket
+		}
+	}
+}
+
+// SubsetOf checks is this set a subset of "other".
+func (s *HashSet[T]) SubsetOf(other *HashSet[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for v := range s.Seq() {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ProperSubsetOf checks is this set a proper subset of "other".
+func (s *HashSet[T]) ProperSubsetOf(other *HashSet[T]) bool {
+	if s.Len() >= other.Len() {
+		return false
+	}
+	return s.SubsetOf(other)
+}
+
+// SupersetOf checks is this set a superset of "other".
+func (s *HashSet[T]) SupersetOf(other *HashSet[T]) bool {
+	return other.SubsetOf(s)
+}
+
+// ProperSupersetOf checks is this set a proper superset of "other".
+func (s *HashSet[T]) ProperSupersetOf(other *HashSet[T]) bool {
+	return other.ProperSubsetOf(s)
+}
+
+// Equal return true if both sets have same elements.
+func (s *HashSet[T]) Equal(other *HashSet[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	return s.SubsetOf(other)
+}
+
+// IsDisjoint checks that sets have no common elements.
+func (s *HashSet[T]) IsDisjoint(other *HashSet[T]) bool {
+	small, big := s, other
+	if small.Len() > big.Len() {
+		small, big = big, small
+	}
+	for v := range small.Seq() {
+		if big.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectionLen returns number of common elements without building a new set.
+func (s *HashSet[T]) IntersectionLen(other *HashSet[T]) int {
+	small, big := s, other
+	if small.Len() > big.Len() {
+		small, big = big, small
+	}
+	count := 0
+	for v := range small.Seq() {
+		if big.Contains(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// ContainsAll checks that every value is in the set. Returns true for no values.
+func (s *HashSet[T]) ContainsAll(values ...T) bool {
+	for _, v := range values {
+		if !s.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny checks that at least one value is in the set. Returns false for no values.
+func (s *HashSet[T]) ContainsAny(values ...T) bool {
+	return slices.ContainsFunc(values, s.Contains)
+}
+
+// Jaccard returns Jaccard similarity: |intersection| / |union|. Two empty sets are equal, so returns 1.
+func (s *HashSet[T]) Jaccard(other *HashSet[T]) float64 {
+	if s.Len() == 0 && other.Len() == 0 {
+		return 1
+	}
+	inter := s.IntersectionLen(other)
+	return float64(inter) / float64(s.Len()+other.Len()-inter)
+}
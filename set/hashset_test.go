@@ -0,0 +1,203 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type hashSetTestSuite struct {
+	suite.Suite
+}
+
+func TestHashSet(t *testing.T) {
+	suite.Run(t, new(hashSetTestSuite))
+}
+
+// collide puts all slices with same length into one bucket.
+func collide(v []int) uint64 {
+	return uint64(len(v))
+}
+
+func newSlices(initial ...[]int) *HashSet[[]int] {
+	return NewHash(collide, slices.Equal[[]int], initial...)
+}
+
+func newFold(initial ...string) *HashSet[string] {
+	hash := func(s string) uint64 {
+		var h uint64
+		for _, r := range strings.ToLower(s) {
+			h = h*31 + uint64(r)
+		}
+		return h
+	}
+	return NewHash(hash, strings.EqualFold, initial...)
+}
+
+func (suite *hashSetTestSuite) sorted(s *HashSet[[]int]) [][]int {
+	return slices.SortedFunc(s.Seq(), slices.Compare[[]int])
+}
+
+func (suite *hashSetTestSuite) TestCollisions() {
+	s := newSlices([]int{1, 2}, []int{3, 4}, []int{1, 2}, []int{5})
+	suite.Require().Equal(3, s.Len())
+	suite.Require().Len(s.buckets, 2)
+	suite.Require().True(s.Contains([]int{3, 4}))
+	suite.Require().False(s.Contains([]int{4, 3}))
+	suite.Require().Equal([][]int{{1, 2}, {3, 4}, {5}}, suite.sorted(s))
+
+	s.Remove([]int{1, 2}, []int{9, 9})
+	suite.Require().Equal(2, s.Len())
+	suite.Require().True(s.Contains([]int{3, 4}))
+	suite.Require().False(s.Contains([]int{1, 2}))
+	s.Remove([]int{3, 4})
+	suite.Require().Len(s.buckets, 1)
+	suite.Require().Equal(1, s.Len())
+
+	s.Clear()
+	suite.Require().Equal(0, s.Len())
+	suite.Require().Empty(slices.Collect(s.Seq()))
+}
+
+func (suite *hashSetTestSuite) TestNormalized() {
+	s := newFold("Go", "GO", "rust")
+	suite.Require().Equal(2, s.Len())
+	suite.Require().True(s.Contains("go"))
+	// the first inserted value is kept
+	suite.Require().Equal([]string{"Go", "rust"}, slices.Sorted(s.Seq()))
+	s.Remove("RUST")
+	suite.Require().False(s.Contains("rust"))
+}
+
+func (suite *hashSetTestSuite) TestAlgebra() {
+	left := newSlices([]int{1}, []int{2}, []int{3, 3})
+	right := newSlices([]int{2}, []int{3, 3}, []int{4})
+
+	suite.Require().Equal([][]int{{1}, {2}, {3, 3}, {4}}, suite.sorted(left.Union(right)))
+	suite.Require().Equal([][]int{{2}, {3, 3}}, suite.sorted(left.Intersection(right)))
+	suite.Require().Equal([][]int{{1}}, suite.sorted(left.Difference(right)))
+	l, r := left.SymmetricDifference(right)
+	suite.Require().Equal([][]int{{1}}, suite.sorted(l))
+	suite.Require().Equal([][]int{{4}}, suite.sorted(r))
+	suite.Require().Equal(3, left.Len())
+	suite.Require().Equal(2, left.IntersectionLen(right))
+
+	c := left.Clone()
+	c.IntersectWith(right)
+	suite.Require().Equal([][]int{{2}, {3, 3}}, suite.sorted(c))
+	suite.Require().Equal(2, c.Len())
+	c.DifferenceWith(newSlices([]int{2}))
+	suite.Require().Equal([][]int{{3, 3}}, suite.sorted(c))
+	c.UnionWith(left)
+	suite.Require().Equal(3, c.Len())
+	suite.Require().True(c.Equal(left))
+	c.RemoveFunc(func(v []int) bool { return len(v) == 1 })
+	suite.Require().Equal([][]int{{3, 3}}, suite.sorted(c))
+	suite.Require().Equal(3, left.Len())
+}
+
+func (suite *hashSetTestSuite) TestCompare() {
+	s := newSlices([]int{1}, []int{2})
+	suite.Require().True(s.SubsetOf(newSlices([]int{2}, []int{1})))
+	suite.Require().False(s.ProperSubsetOf(newSlices([]int{2}, []int{1})))
+	suite.Require().True(s.ProperSubsetOf(newSlices([]int{2}, []int{1}, []int{3})))
+	suite.Require().False(s.SubsetOf(newSlices([]int{1}, []int{3})))
+	suite.Require().True(s.SupersetOf(newSlices([]int{1})))
+	suite.Require().True(s.ProperSupersetOf(newSlices([]int{1})))
+	suite.Require().False(s.Equal(newSlices([]int{1}, []int{3})))
+	suite.Require().True(s.IsDisjoint(newSlices([]int{3})))
+	suite.Require().False(s.IsDisjoint(newSlices([]int{3}, []int{2})))
+	suite.Require().True(s.ContainsAll([]int{1}, []int{2}))
+	suite.Require().False(s.ContainsAll([]int{1}, []int{3}))
+	suite.Require().True(s.ContainsAny([]int{3}, []int{2}))
+	suite.Require().False(s.ContainsAny())
+}
+
+func (suite *hashSetTestSuite) TestSetAliases() {
+	left := newSlices([]int{1}, []int{2}, []int{3, 3})
+	right := newSlices([]int{2}, []int{3, 3}, []int{4})
+
+	suite.Require().Equal([][]int{{1}, {4}}, suite.sorted(left.SymmetricDifferenceSet(right)))
+	suite.Require().Equal(0.5, left.Jaccard(right))
+	suite.Require().Equal(1.0, newSlices().Jaccard(newSlices()))
+	suite.Require().Equal(0.0, left.Jaccard(newSlices()))
+
+	c := left.Clone()
+	c.InsertSet(right)
+	suite.Require().Equal(4, c.Len())
+	c.RemoveAll(newSlices([]int{1}, []int{4}))
+	suite.Require().Equal([][]int{{2}, {3, 3}}, suite.sorted(c))
+	c.RetainAll(newSlices([]int{3, 3}))
+	suite.Require().Equal([][]int{{3, 3}}, suite.sorted(c))
+}
+
+func (suite *hashSetTestSuite) TestCombinations() {
+	s := newFold("a", "b", "c")
+	count := 0
+	for subset := range s.PowerSet() {
+		suite.Require().True(subset.SubsetOf(s))
+		// subsets keep functions of the set
+		subset.Insert("A")
+		suite.Require().True(subset.Contains("a"))
+		count++
+	}
+	suite.Require().Equal(8, count)
+
+	for k, expected := range []int{1, 3, 3, 1} {
+		count = 0
+		for subset := range s.Combinations(k) {
+			suite.Require().Equal(k, subset.Len())
+			suite.Require().True(subset.SubsetOf(s))
+			count++
+		}
+		suite.Require().Equal(expected, count, "k=%d", k)
+	}
+	suite.Require().Empty(slices.Collect(s.Combinations(4)))
+}
+
+func (suite *hashSetTestSuite) TestEncoding() {
+	s := newFold("b", "a", "c")
+
+	data, err := json.Marshal(s)
+	suite.Require().NoError(err)
+	suite.Require().Equal(`["a","b","c"]`, string(data))
+	decoded := newFold("x")
+	suite.Require().NoError(json.Unmarshal([]byte(`["A","a","B"]`), decoded))
+	suite.Require().Equal(2, decoded.Len())
+	suite.Require().True(decoded.Contains("b"))
+	suite.Require().False(decoded.Contains("x"))
+	suite.Require().NoError(json.Unmarshal([]byte(`null`), decoded))
+	suite.Require().Equal(2, decoded.Len())
+	suite.Require().ErrorIs(json.Unmarshal(data, &HashSet[string]{}), ErrNoHash)
+
+	data, err = newFold().MarshalJSON()
+	suite.Require().NoError(err)
+	suite.Require().Equal(`[]`, string(data))
+
+	var buf bytes.Buffer
+	suite.Require().NoError(gob.NewEncoder(&buf).Encode(s))
+	decoded = newFold()
+	suite.Require().NoError(gob.NewDecoder(&buf).Decode(decoded))
+	suite.Require().True(decoded.Equal(s))
+
+	data, err = yaml.Marshal(s)
+	suite.Require().NoError(err)
+	suite.Require().Equal("- a\n- b\n- c\n", string(data))
+	decoded = newFold()
+	suite.Require().NoError(yaml.Unmarshal(data, decoded))
+	suite.Require().True(decoded.Equal(s))
+	suite.Require().Error(yaml.Unmarshal([]byte("a: 1"), decoded))
+
+	// non-comparable elements are kept in random order
+	slicesSet := newSlices([]int{1}, []int{2, 3})
+	data, err = json.Marshal(slicesSet)
+	suite.Require().NoError(err)
+	decodedSlices := newSlices()
+	suite.Require().NoError(json.Unmarshal(data, decodedSlices))
+	suite.Require().True(decodedSlices.Equal(slicesSet))
+}
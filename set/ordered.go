@@ -0,0 +1,184 @@
+package set
+
+import (
+	"iter"
+)
+
+type orderedNode[T comparable] struct {
+	value T
+	prev  *orderedNode[T]
+	next  *orderedNode[T]
+}
+
+// OrderedSet is a set which remembers the insertion order (linked hash set).
+// Insert, Remove and Contains are O(1), iteration follows the order of insertion.
+// The zero value is an empty set ready to use.
+type OrderedSet[T comparable] struct {
+	hash  map[T]*orderedNode[T]
+	first *orderedNode[T]
+	last  *orderedNode[T]
+}
+
+// NewOrdered creates a new insertion-ordered set.
+func NewOrdered[T comparable](initial ...T) *OrderedSet[T] {
+	s := &OrderedSet[T]{hash: make(map[T]*orderedNode[T], len(initial))}
+	s.Insert(initial...)
+	return s
+}
+
+// Len returns number of items in the set.
+func (s *OrderedSet[T]) Len() int {
+	return len(s.hash)
+}
+
+// Contains checks is element in the set or not.
+func (s *OrderedSet[T]) Contains(element T) bool {
+	_, exists := s.hash[element]
+	return exists
+}
+
+// Insert adds elements to the end. Existing elements keep their position.
+func (s *OrderedSet[T]) Insert(elements ...T) {
+	if s.hash == nil {
+		s.hash = make(map[T]*orderedNode[T], len(elements))
+	}
+	for _, e := range elements {
+		if _, exists := s.hash[e]; exists {
+			continue
+		}
+		n := &orderedNode[T]{value: e, prev: s.last}
+		if s.last != nil {
+			s.last.next = n
+		} else {
+			s.first = n
+		}
+		s.last = n
+		s.hash[e] = n
+	}
+}
+
+// Remove removes elements from the set. Missing elements do nothing.
+func (s *OrderedSet[T]) Remove(elements ...T) {
+	for _, e := range elements {
+		n, exists := s.hash[e]
+		if !exists {
+			continue
+		}
+		delete(s.hash, e)
+		if n.prev != nil {
+			n.prev.next = n.next
+		} else {
+			s.first = n.next
+		}
+		if n.next != nil {
+			n.next.prev = n.prev
+		} else {
+			s.last = n.prev
+		}
+		n.prev = nil
+		n.next = nil
+	}
+}
+
+// Clear removes all elements.
+func (s *OrderedSet[T]) Clear() {
+	s.hash = nil
+	s.first = nil
+	s.last = nil
+}
+
+// Seq returns value-iterator in the insertion order.
+func (s *OrderedSet[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.first; n != nil; n = n.next {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// ReversedSeq returns value-iterator in the reversed insertion order.
+func (s *OrderedSet[T]) ReversedSeq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.last; n != nil; n = n.prev {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Intersection returns elements which are in both sets in the order of this set.
+func (s *OrderedSet[T]) Intersection(other *OrderedSet[T]) *OrderedSet[T] {
+	res := NewOrdered[T]()
+	for v := range s.Seq() {
+		if other.Contains(v) {
+			res.Insert(v)
+		}
+	}
+	return res
+}
+
+// SymmetricDifference returns unique elements for both sets in the order of each set.
+func (s *OrderedSet[T]) SymmetricDifference(other *OrderedSet[T]) (*OrderedSet[T], *OrderedSet[T]) {
+	return s.Difference(other), other.Difference(s)
+}
+
+// Difference returns unique elements for that set in the order of this set.
+func (s *OrderedSet[T]) Difference(other *OrderedSet[T]) *OrderedSet[T] {
+	res := NewOrdered[T]()
+	for v := range s.Seq() {
+		if !other.Contains(v) {
+			res.Insert(v)
+		}
+	}
+	return res
+}
+
+// Union returns all elements of both sets: elements of this set and then new elements of "other".
+func (s *OrderedSet[T]) Union(other *OrderedSet[T]) *OrderedSet[T] {
+	res := s.Clone()
+	for v := range other.Seq() {
+		res.Insert(v)
+	}
+	return res
+}
+
+// SubsetOf checks is this set a subset of "other".
+func (s *OrderedSet[T]) SubsetOf(other *OrderedSet[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for k := range s.hash {
+		if !other.Contains(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// ProperSubsetOf checks is this set a proper subset of "other".
+func (s *OrderedSet[T]) ProperSubsetOf(other *OrderedSet[T]) bool {
+	if s.Len() >= other.Len() {
+		return false
+	}
+	return s.SubsetOf(other)
+}
+
+// Equal return true if both sets have same elements. The order is not compared.
+func (s *OrderedSet[T]) Equal(other *OrderedSet[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	return s.SubsetOf(other)
+}
+
+// Clone returns a new set with same elements in the same order.
+func (s *OrderedSet[T]) Clone() *OrderedSet[T] {
+	res := &OrderedSet[T]{hash: make(map[T]*orderedNode[T], s.Len())}
+	for v := range s.Seq() {
+		res.Insert(v)
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package set
+
+import (
+	"github.com/stretchr/testify/suite"
+	"slices"
+	"testing"
+)
+
+type orderedSetTestSuite struct {
+	suite.Suite
+}
+
+func TestOrderedSet(t *testing.T) {
+	suite.Run(t, new(orderedSetTestSuite))
+}
+
+func (suite *orderedSetTestSuite) TestInsertRemove() {
+	s := NewOrdered(3, 1, 2, 1)
+	suite.Require().Equal(3, s.Len())
+	suite.Require().Equal([]int{3, 1, 2}, slices.Collect(s.Seq()))
+	suite.Require().Equal([]int{2, 1, 3}, slices.Collect(s.ReversedSeq()))
+
+	s.Insert(3, 5)
+	suite.Require().Equal([]int{3, 1, 2, 5}, slices.Collect(s.Seq()))
+
+	s.Remove(1, 10)
+	suite.Require().False(s.Contains(1))
+	suite.Require().Equal([]int{3, 2, 5}, slices.Collect(s.Seq()))
+	s.Remove(3)
+	suite.Require().Equal([]int{2, 5}, slices.Collect(s.Seq()))
+	s.Remove(5)
+	suite.Require().Equal([]int{2}, slices.Collect(s.ReversedSeq()))
+	s.Remove(2)
+	suite.Require().Equal(0, s.Len())
+	suite.Require().Empty(slices.Collect(s.Seq()))
+	suite.Require().Empty(slices.Collect(s.ReversedSeq()))
+
+	// removed element goes to the end
+	s.Insert(1, 2)
+	s.Remove(1)
+	s.Insert(1)
+	suite.Require().Equal([]int{2, 1}, slices.Collect(s.Seq()))
+
+	for v := range s.Seq() {
+		suite.Require().Equal(2, v)
+		break
+	}
+}
+
+func (suite *orderedSetTestSuite) TestAlgebra() {
+	left := NewOrdered(5, 1, 4, 2)
+	right := NewOrdered(2, 9, 5, 7)
+
+	suite.Require().Equal([]int{5, 1, 4, 2, 9, 7}, slices.Collect(left.Union(right).Seq()))
+	suite.Require().Equal([]int{5, 2}, slices.Collect(left.Intersection(right).Seq()))
+	suite.Require().Equal([]int{2, 5}, slices.Collect(right.Intersection(left).Seq()))
+	suite.Require().Equal([]int{1, 4}, slices.Collect(left.Difference(right).Seq()))
+	l, r := left.SymmetricDifference(right)
+	suite.Require().Equal([]int{1, 4}, slices.Collect(l.Seq()))
+	suite.Require().Equal([]int{9, 7}, slices.Collect(r.Seq()))
+}
+
+func (suite *orderedSetTestSuite) TestCompare() {
+	s := NewOrdered(1, 2)
+	suite.Require().True(s.SubsetOf(NewOrdered(2, 1)))
+	suite.Require().False(s.ProperSubsetOf(NewOrdered(2, 1)))
+	suite.Require().True(s.ProperSubsetOf(NewOrdered(3, 2, 1)))
+	suite.Require().False(s.SubsetOf(NewOrdered(1, 3)))
+	suite.Require().True(s.Equal(NewOrdered(2, 1)))
+	suite.Require().False(s.Equal(NewOrdered(2, 3)))
+	suite.Require().True(NewOrdered[int]().SubsetOf(s))
+}
+
+func (suite *orderedSetTestSuite) TestCloneClear() {
+	s := NewOrdered(3, 1, 2)
+	c := s.Clone()
+	s.Clear()
+	suite.Require().Equal(0, s.Len())
+	suite.Require().Equal([]int{3, 1, 2}, slices.Collect(c.Seq()))
+	s.Insert(4)
+	suite.Require().Equal([]int{4}, slices.Collect(s.Seq()))
+}
+
+func (suite *orderedSetTestSuite) TestZeroValue() {
+	var s OrderedSet[int]
+	suite.Require().Equal(0, s.Len())
+	suite.Require().False(s.Contains(1))
+	s.Remove(1)
+	suite.Require().Empty(slices.Collect(s.Seq()))
+	suite.Require().Equal(0, s.Clone().Len())
+	s.Insert(2, 1)
+	suite.Require().Equal([]int{2, 1}, slices.Collect(s.Seq()))
+}
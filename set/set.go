@@ -4,17 +4,19 @@ package set
 import (
 	"iter"
 	"maps"
+	"slices"
 )
 
 type (
-	empty              struct{}
+	empty struct{}
+	// Set is an unordered set of unique values. The zero value is an empty set ready to use.
 	Set[ST comparable] struct {
 		hash map[ST]empty
 	}
 )
 
 func New[T comparable](initial ...T) *Set[T] {
-	s := &Set[T]{make(map[T]empty)}
+	s := &Set[T]{hash: make(map[T]empty)}
 
 	for _, v := range initial {
 		s.Insert(v)
@@ -42,6 +44,9 @@ func (s *Set[ST]) Contains(element ST) bool {
 
 // Insert adds an element to the set.
 func (s *Set[ST]) Insert(elements ...ST) {
+	if s.hash == nil {
+		s.hash = make(map[ST]empty, len(elements))
+	}
 	for _, e := range elements {
 		s.hash[e] = empty{}
 	}
@@ -57,7 +62,7 @@ func (s *Set[ST]) Intersection(set *Set[ST]) *Set[ST] {
 		}
 	}
 
-	return &Set[ST]{n}
+	return &Set[ST]{hash: n}
 }
 
 // SymmetricDifference returns unique elements for both sets.
@@ -87,7 +92,7 @@ func (s *Set[ST]) Difference(other *Set[ST]) *Set[ST] {
 		}
 	}
 
-	return &Set[ST]{n}
+	return &Set[ST]{hash: n}
 }
 
 // Len returns number of items in the set.
@@ -138,11 +143,14 @@ func (s *Set[ST]) Union(set *Set[ST]) *Set[ST] {
 		n[k] = empty{}
 	}
 
-	return &Set[ST]{n}
+	return &Set[ST]{hash: n}
 }
 
 // Clone returns a new set with same elements.
 func (s *Set[ST]) Clone() *Set[ST] {
+	if s.hash == nil {
+		return New[ST]()
+	}
 	return &Set[ST]{
 		hash: maps.Clone(s.hash),
 	}
@@ -160,3 +168,178 @@ func (s *Set[ST]) Equal(other *Set[ST]) bool {
 	}
 	return true
 }
+
+// UnionWith adds all elements of "other" to this set in place.
+func (s *Set[ST]) UnionWith(other *Set[ST]) {
+	if s.hash == nil {
+		s.hash = make(map[ST]empty, other.Len())
+	}
+	for k := range other.hash {
+		s.hash[k] = empty{}
+	}
+}
+
+// InsertSet same as UnionWith.
+func (s *Set[ST]) InsertSet(other *Set[ST]) {
+	s.UnionWith(other)
+}
+
+// IntersectWith keeps only elements which are in "other" in place.
+func (s *Set[ST]) IntersectWith(other *Set[ST]) {
+	for k := range s.hash {
+		if _, exists := other.hash[k]; !exists {
+			delete(s.hash, k)
+		}
+	}
+}
+
+// RetainAll same as IntersectWith.
+func (s *Set[ST]) RetainAll(other *Set[ST]) {
+	s.IntersectWith(other)
+}
+
+// DifferenceWith removes all elements of "other" from this set in place.
+func (s *Set[ST]) DifferenceWith(other *Set[ST]) {
+	// iterate the smaller side
+	if s.Len() < other.Len() {
+		for k := range s.hash {
+			if _, exists := other.hash[k]; exists {
+				delete(s.hash, k)
+			}
+		}
+		return
+	}
+	for k := range other.hash {
+		delete(s.hash, k)
+	}
+}
+
+// RemoveAll same as DifferenceWith.
+func (s *Set[ST]) RemoveAll(other *Set[ST]) {
+	s.DifferenceWith(other)
+}
+
+// SymmetricDifferenceSet returns elements which are in only one of the sets.
+func (s *Set[ST]) SymmetricDifferenceSet(other *Set[ST]) *Set[ST] {
+	n := make(map[ST]empty)
+	for k := range s.hash {
+		if _, exists := other.hash[k]; !exists {
+			n[k] = empty{}
+		}
+	}
+	for k := range other.hash {
+		if _, exists := s.hash[k]; !exists {
+			n[k] = empty{}
+		}
+	}
+	return &Set[ST]{hash: n}
+}
+
+// UnionAll returns all elements of all sets. Nil sets are skipped.
+func UnionAll[T comparable](sets ...*Set[T]) *Set[T] {
+	size := 0
+	for _, s := range sets {
+		if s != nil {
+			size = max(size, s.Len())
+		}
+	}
+	res := &Set[T]{hash: make(map[T]empty, size)}
+	for _, s := range sets {
+		if s != nil {
+			res.UnionWith(s)
+		}
+	}
+	return res
+}
+
+// IntersectAll returns elements which are in every set. Starts from the smallest set.
+// Returns an empty set if there are no sets or any of them is nil.
+func IntersectAll[T comparable](sets ...*Set[T]) *Set[T] {
+	if len(sets) == 0 || slices.Contains(sets, nil) {
+		return New[T]()
+	}
+	smallest := slices.MinFunc(sets, func(a, b *Set[T]) int {
+		return a.Len() - b.Len()
+	})
+	res := smallest.Clone()
+	for _, s := range sets {
+		if s == smallest {
+			continue
+		}
+		if res.Len() == 0 {
+			break
+		}
+		res.IntersectWith(s)
+	}
+	return res
+}
+
+// smaller returns the set with fewer elements first.
+func (s *Set[ST]) smaller(other *Set[ST]) (*Set[ST], *Set[ST]) {
+	if s.Len() <= other.Len() {
+		return s, other
+	}
+	return other, s
+}
+
+// IsDisjoint checks that sets have no common elements.
+func (s *Set[ST]) IsDisjoint(other *Set[ST]) bool {
+	small, big := s.smaller(other)
+	for k := range small.hash {
+		if _, exists := big.hash[k]; exists {
+			return false
+		}
+	}
+	return true
+}
+
+// SupersetOf checks is this set a superset of "other".
+func (s *Set[ST]) SupersetOf(other *Set[ST]) bool {
+	return other.SubsetOf(s)
+}
+
+// ProperSupersetOf checks is this set a proper superset of "other".
+func (s *Set[ST]) ProperSupersetOf(other *Set[ST]) bool {
+	return other.ProperSubsetOf(s)
+}
+
+// ContainsAll checks that every value is in the set. Returns true for no values.
+func (s *Set[ST]) ContainsAll(values ...ST) bool {
+	for _, v := range values {
+		if _, exists := s.hash[v]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny checks that at least one value is in the set. Returns false for no values.
+func (s *Set[ST]) ContainsAny(values ...ST) bool {
+	for _, v := range values {
+		if _, exists := s.hash[v]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectionLen returns number of common elements without building a new set.
+func (s *Set[ST]) IntersectionLen(other *Set[ST]) int {
+	small, big := s.smaller(other)
+	count := 0
+	for k := range small.hash {
+		if _, exists := big.hash[k]; exists {
+			count++
+		}
+	}
+	return count
+}
+
+// Jaccard returns Jaccard similarity: |intersection| / |union|. Two empty sets are equal, so returns 1.
+func (s *Set[ST]) Jaccard(other *Set[ST]) float64 {
+	if s.Len() == 0 && other.Len() == 0 {
+		return 1
+	}
+	inter := s.IntersectionLen(other)
+	return float64(inter) / float64(s.Len()+other.Len()-inter)
+}
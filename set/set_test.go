@@ -291,3 +291,129 @@ func (suite *setTestSuite) TestEqual() {
 	suite.Require().False(New[int](1, 2, 3).Equal(New[int](1, 2)))
 
 }
+
+func (suite *setTestSuite) TestZeroValue() {
+	var s Set[int]
+	suite.Require().Equal(0, s.Len())
+	suite.Require().False(s.Contains(1))
+	s.Remove(1)
+	for range s.Seq() {
+		suite.Fail("should not be reached")
+	}
+	suite.Require().True(s.SubsetOf(New(1)))
+	suite.Require().False(New(1).SubsetOf(&s))
+	suite.Require().True(s.ProperSubsetOf(New(1)))
+	suite.Require().True(s.Equal(New[int]()))
+	suite.Require().Equal(0, s.Intersection(New(1)).Len())
+	suite.Require().Equal(1, s.Union(New(1)).Len())
+	suite.Require().Equal(0, s.Difference(New(1)).Len())
+	suite.Require().Equal(1, New(1).Difference(&s).Len())
+	left, right := s.SymmetricDifference(New(1))
+	suite.Require().Equal(0, left.Len())
+	suite.Require().Equal(1, right.Len())
+
+	c := s.Clone()
+	c.Insert(1)
+	suite.Require().Equal(0, s.Len())
+
+	s.Insert(1, 2)
+	suite.Require().Equal(newTestMap(1, 2), s.hash)
+}
+
+func (suite *setTestSuite) TestInPlace() {
+	s := New(1, 2, 3)
+	s.UnionWith(New(3, 4))
+	suite.Require().Equal(newTestMap(1, 2, 3, 4), s.hash)
+	s.InsertSet(New(5))
+	suite.Require().Equal(newTestMap(1, 2, 3, 4, 5), s.hash)
+
+	s.IntersectWith(New(1, 2, 3, 4, 10))
+	suite.Require().Equal(newTestMap(1, 2, 3, 4), s.hash)
+	s.RetainAll(New(2, 3, 4))
+	suite.Require().Equal(newTestMap(2, 3, 4), s.hash)
+
+	s.DifferenceWith(New(4, 10))
+	suite.Require().Equal(newTestMap(2, 3), s.hash)
+	s.RemoveAll(New(1, 2, 5, 6, 7))
+	suite.Require().Equal(newTestMap(3), s.hash)
+
+	// same set
+	s = New(1, 2)
+	s.UnionWith(s)
+	s.IntersectWith(s)
+	suite.Require().Equal(newTestMap(1, 2), s.hash)
+	s.DifferenceWith(s)
+	suite.Require().Equal(newTestMap(), s.hash)
+
+	var zero Set[int]
+	zero.UnionWith(New(1))
+	suite.Require().Equal(newTestMap(1), zero.hash)
+}
+
+func (suite *setTestSuite) TestSymmetricDifferenceSet() {
+	suite.Require().Equal(newTestMap(1, 4, 5), New(1, 2, 3).SymmetricDifferenceSet(New(2, 3, 4, 5)).hash)
+	suite.Require().Equal(newTestMap(), New(1).SymmetricDifferenceSet(New(1)).hash)
+	suite.Require().Equal(newTestMap(1), New[int]().SymmetricDifferenceSet(New(1)).hash)
+}
+
+func (suite *setTestSuite) TestUnionAll() {
+	suite.Require().Equal(newTestMap(), UnionAll[int]().hash)
+	suite.Require().Equal(newTestMap(1, 2, 3, 4), UnionAll(New(1), New(2, 3), nil, New(3, 4)).hash)
+}
+
+func (suite *setTestSuite) TestIntersectAll() {
+	suite.Require().Equal(newTestMap(), IntersectAll[int]().hash)
+	suite.Require().Equal(newTestMap(), IntersectAll(New(1), nil).hash)
+	a := New(1, 2, 3, 4)
+	b := New(2, 3, 4)
+	c := New(3, 4, 5)
+	res := IntersectAll(a, b, c)
+	suite.Require().Equal(newTestMap(3, 4), res.hash)
+	// arguments are not changed
+	suite.Require().Equal(newTestMap(2, 3, 4), b.hash)
+	suite.Require().Equal(newTestMap(), IntersectAll(a, New[int](), c).hash)
+	suite.Require().Equal(newTestMap(1, 2), IntersectAll(New(1, 2)).hash)
+}
+
+func (suite *setTestSuite) TestIsDisjoint() {
+	suite.Require().True(New(1, 2).IsDisjoint(New(3, 4, 5)))
+	suite.Require().False(New(1, 2).IsDisjoint(New(3, 2, 5)))
+	suite.Require().False(New(3, 2, 5).IsDisjoint(New(1, 2)))
+	suite.Require().True(New[int]().IsDisjoint(New[int]()))
+	suite.Require().True(New(1).IsDisjoint(New[int]()))
+}
+
+func (suite *setTestSuite) TestSupersetOf() {
+	suite.Require().True(New(1, 2, 3).SupersetOf(New(1, 2)))
+	suite.Require().True(New(1, 2).SupersetOf(New(1, 2)))
+	suite.Require().True(New(1, 2).SupersetOf(New[int]()))
+	suite.Require().False(New(1, 2).SupersetOf(New(1, 4)))
+	suite.Require().False(New(1).SupersetOf(New(1, 2)))
+
+	suite.Require().True(New(1, 2, 3).ProperSupersetOf(New(1, 2)))
+	suite.Require().False(New(1, 2).ProperSupersetOf(New(1, 2)))
+	suite.Require().False(New(1, 2, 3).ProperSupersetOf(New(1, 4)))
+}
+
+func (suite *setTestSuite) TestContainsAllAny() {
+	s := New(1, 2, 3)
+	suite.Require().True(s.ContainsAll())
+	suite.Require().True(s.ContainsAll(1, 3))
+	suite.Require().False(s.ContainsAll(1, 4))
+	suite.Require().False(s.ContainsAny())
+	suite.Require().True(s.ContainsAny(5, 3))
+	suite.Require().False(s.ContainsAny(5, 6))
+}
+
+func (suite *setTestSuite) TestIntersectionLen() {
+	suite.Require().Equal(2, New(1, 2, 3).IntersectionLen(New(2, 3, 4, 5)))
+	suite.Require().Equal(2, New(2, 3, 4, 5).IntersectionLen(New(1, 2, 3)))
+	suite.Require().Equal(0, New(1).IntersectionLen(New[int]()))
+}
+
+func (suite *setTestSuite) TestJaccard() {
+	suite.Require().Equal(1.0, New[int]().Jaccard(New[int]()))
+	suite.Require().Equal(0.0, New(1).Jaccard(New[int]()))
+	suite.Require().Equal(1.0, New(1, 2).Jaccard(New(2, 1)))
+	suite.Require().Equal(0.4, New(1, 2, 3).Jaccard(New(2, 3, 4, 5)))
+}
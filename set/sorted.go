@@ -0,0 +1,570 @@
+package set
+
+import (
+	"iter"
+)
+
+type sortedNode[T any] struct {
+	value  T
+	left   *sortedNode[T]
+	right  *sortedNode[T]
+	parent *sortedNode[T]
+	red    bool
+	// number of nodes in the subtree
+	size int
+}
+
+// SortedSet is an ordered set backed by a red-black tree with subtree sizes.
+// Insert, Remove, Contains, Rank and Select are O(log N), iteration is ordered.
+type SortedSet[T any] struct {
+	root *sortedNode[T]
+	// sentinel for all leaves and the parent of the root, always black with size 0
+	leaf *sortedNode[T]
+	cmp  func(a, b T) int
+}
+
+// NewSorted creates a new sorted set. cmp returns a negative number when a < b,
+// a positive number when a > b and zero when elements are equal (like cmp.Compare).
+func NewSorted[T any](cmp func(a, b T) int, initial ...T) *SortedSet[T] {
+	leaf := &sortedNode[T]{}
+	s := &SortedSet[T]{root: leaf, leaf: leaf, cmp: cmp}
+	s.Insert(initial...)
+	return s
+}
+
+// Len returns number of items in the set.
+func (s *SortedSet[T]) Len() int {
+	return s.root.size
+}
+
+func (s *SortedSet[T]) search(element T) *sortedNode[T] {
+	n := s.root
+	for n != s.leaf {
+		c := s.cmp(element, n.value)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Contains checks is element in the set or not.
+func (s *SortedSet[T]) Contains(element T) bool {
+	return s.search(element) != nil
+}
+
+// Insert adds elements to the set. Elements equal to existing ones are ignored.
+func (s *SortedSet[T]) Insert(elements ...T) {
+	for _, e := range elements {
+		s.insert(e)
+	}
+}
+
+func (s *SortedSet[T]) insert(element T) {
+	if s.Contains(element) {
+		return
+	}
+	parent := s.leaf
+	cur := s.root
+	var c int
+	for cur != s.leaf {
+		cur.size++
+		parent = cur
+		c = s.cmp(element, cur.value)
+		if c < 0 {
+			cur = cur.left
+		} else {
+			cur = cur.right
+		}
+	}
+	n := &sortedNode[T]{value: element, left: s.leaf, right: s.leaf, parent: parent, red: true, size: 1}
+	switch {
+	case parent == s.leaf:
+		s.root = n
+	case c < 0:
+		parent.left = n
+	default:
+		parent.right = n
+	}
+	s.insertFixup(n)
+}
+
+func (s *SortedSet[T]) insertFixup(n *sortedNode[T]) {
+	for n.parent.red {
+		p := n.parent
+		g := p.parent
+		if p == g.left {
+			uncle := g.right
+			if uncle.red {
+				p.red, uncle.red, g.red = false, false, true
+				n = g
+				continue
+			}
+			if n == p.right {
+				n = p
+				s.rotateLeft(n)
+				p = n.parent
+			}
+			p.red, g.red = false, true
+			s.rotateRight(g)
+		} else {
+			uncle := g.left
+			if uncle.red {
+				p.red, uncle.red, g.red = false, false, true
+				n = g
+				continue
+			}
+			if n == p.left {
+				n = p
+				s.rotateRight(n)
+				p = n.parent
+			}
+			p.red, g.red = false, true
+			s.rotateLeft(g)
+		}
+	}
+	s.root.red = false
+}
+
+func (s *SortedSet[T]) rotateLeft(x *sortedNode[T]) {
+	y := x.right
+	x.right = y.left
+	if y.left != s.leaf {
+		y.left.parent = x
+	}
+	s.replaceChild(x, y)
+	y.left = x
+	x.parent = y
+	y.size = x.size
+	x.size = x.left.size + x.right.size + 1
+}
+
+func (s *SortedSet[T]) rotateRight(x *sortedNode[T]) {
+	y := x.left
+	x.left = y.right
+	if y.right != s.leaf {
+		y.right.parent = x
+	}
+	s.replaceChild(x, y)
+	y.right = x
+	x.parent = y
+	y.size = x.size
+	x.size = x.left.size + x.right.size + 1
+}
+
+// replaceChild puts 'n' to the place of 'old' in its parent.
+func (s *SortedSet[T]) replaceChild(old, n *sortedNode[T]) {
+	n.parent = old.parent
+	switch {
+	case old.parent == s.leaf:
+		s.root = n
+	case old == old.parent.left:
+		old.parent.left = n
+	default:
+		old.parent.right = n
+	}
+}
+
+// Remove removes elements from the set. Missing elements do nothing.
+func (s *SortedSet[T]) Remove(elements ...T) {
+	for _, e := range elements {
+		if n := s.search(e); n != nil {
+			s.remove(n)
+		}
+	}
+}
+
+func (s *SortedSet[T]) remove(z *sortedNode[T]) {
+	// y is the node which leaves its place in the tree
+	y := z
+	if z.left != s.leaf && z.right != s.leaf {
+		y = s.minNode(z.right)
+	}
+	for p := y.parent; p != s.leaf; p = p.parent {
+		p.size--
+	}
+
+	removedRed := y.red
+	var x *sortedNode[T]
+	switch {
+	case z.left == s.leaf:
+		x = z.right
+		s.replaceChild(z, x)
+	case z.right == s.leaf:
+		x = z.left
+		s.replaceChild(z, x)
+	default:
+		x = y.right
+		if y.parent == z {
+			x.parent = y
+		} else {
+			s.replaceChild(y, x)
+			y.right = z.right
+			y.right.parent = y
+		}
+		s.replaceChild(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.red = z.red
+		y.size = z.size
+	}
+	z.left, z.right, z.parent = nil, nil, nil
+	if !removedRed {
+		s.removeFixup(x)
+	}
+	// the sentinel could get a parent
+	s.leaf.parent = nil
+}
+
+func (s *SortedSet[T]) removeFixup(x *sortedNode[T]) {
+	for x != s.root && !x.red {
+		p := x.parent
+		if x == p.left {
+			w := p.right
+			if w.red {
+				w.red, p.red = false, true
+				s.rotateLeft(p)
+				w = p.right
+			}
+			if !w.left.red && !w.right.red {
+				w.red = true
+				x = p
+				continue
+			}
+			if !w.right.red {
+				w.left.red, w.red = false, true
+				s.rotateRight(w)
+				w = p.right
+			}
+			w.red, p.red, w.right.red = p.red, false, false
+			s.rotateLeft(p)
+			x = s.root
+		} else {
+			w := p.left
+			if w.red {
+				w.red, p.red = false, true
+				s.rotateRight(p)
+				w = p.left
+			}
+			if !w.left.red && !w.right.red {
+				w.red = true
+				x = p
+				continue
+			}
+			if !w.left.red {
+				w.right.red, w.red = false, true
+				s.rotateLeft(w)
+				w = p.left
+			}
+			w.red, p.red, w.left.red = p.red, false, false
+			s.rotateRight(p)
+			x = s.root
+		}
+	}
+	x.red = false
+}
+
+func (s *SortedSet[T]) minNode(n *sortedNode[T]) *sortedNode[T] {
+	for n.left != s.leaf {
+		n = n.left
+	}
+	return n
+}
+
+func (s *SortedSet[T]) maxNode(n *sortedNode[T]) *sortedNode[T] {
+	for n.right != s.leaf {
+		n = n.right
+	}
+	return n
+}
+
+func (s *SortedSet[T]) next(n *sortedNode[T]) *sortedNode[T] {
+	if n.right != s.leaf {
+		return s.minNode(n.right)
+	}
+	p := n.parent
+	for p != s.leaf && n == p.right {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+func (s *SortedSet[T]) prev(n *sortedNode[T]) *sortedNode[T] {
+	if n.left != s.leaf {
+		return s.maxNode(n.left)
+	}
+	p := n.parent
+	for p != s.leaf && n == p.left {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+// Min returns the smallest element. If the set is empty returns default value and 'false'.
+func (s *SortedSet[T]) Min() (res T, exists bool) {
+	if s.root == s.leaf {
+		return
+	}
+	return s.minNode(s.root).value, true
+}
+
+// Max returns the biggest element. If the set is empty returns default value and 'false'.
+func (s *SortedSet[T]) Max() (res T, exists bool) {
+	if s.root == s.leaf {
+		return
+	}
+	return s.maxNode(s.root).value, true
+}
+
+// floorNode returns the node with the biggest value <= element or nil.
+func (s *SortedSet[T]) floorNode(element T) *sortedNode[T] {
+	var res *sortedNode[T]
+	n := s.root
+	for n != s.leaf {
+		c := s.cmp(element, n.value)
+		if c == 0 {
+			return n
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			res = n
+			n = n.right
+		}
+	}
+	return res
+}
+
+// ceilingNode returns the node with the smallest value >= element or nil.
+func (s *SortedSet[T]) ceilingNode(element T) *sortedNode[T] {
+	var res *sortedNode[T]
+	n := s.root
+	for n != s.leaf {
+		c := s.cmp(element, n.value)
+		if c == 0 {
+			return n
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			res = n
+			n = n.left
+		}
+	}
+	return res
+}
+
+// Floor returns the biggest element less than or equal to the given one.
+// If there is no such element returns default value and 'false'.
+func (s *SortedSet[T]) Floor(element T) (res T, exists bool) {
+	if n := s.floorNode(element); n != nil {
+		return n.value, true
+	}
+	return
+}
+
+// Ceiling returns the smallest element greater than or equal to the given one.
+// If there is no such element returns default value and 'false'.
+func (s *SortedSet[T]) Ceiling(element T) (res T, exists bool) {
+	if n := s.ceilingNode(element); n != nil {
+		return n.value, true
+	}
+	return
+}
+
+// Rank returns the number of elements less than the given one.
+func (s *SortedSet[T]) Rank(element T) int {
+	rank := 0
+	n := s.root
+	for n != s.leaf {
+		if s.cmp(element, n.value) <= 0 {
+			n = n.left
+		} else {
+			rank += n.left.size + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// Select returns the k-th smallest element (starts from 0).
+// If k is out of range returns default value and 'false'.
+func (s *SortedSet[T]) Select(k int) (res T, exists bool) {
+	if k < 0 || k >= s.Len() {
+		return
+	}
+	n := s.root
+	for {
+		switch l := n.left.size; {
+		case k < l:
+			n = n.left
+		case k > l:
+			k -= l + 1
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+}
+
+// Seq returns value-iterator in ascending order. The set must not be changed during iteration.
+func (s *SortedSet[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.root == s.leaf {
+			return
+		}
+		for n := s.minNode(s.root); n != s.leaf; n = s.next(n) {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// ReversedSeq returns value-iterator in descending order. The set must not be changed during iteration.
+func (s *SortedSet[T]) ReversedSeq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.root == s.leaf {
+			return
+		}
+		for n := s.maxNode(s.root); n != s.leaf; n = s.prev(n) {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns ascending value-iterator over elements lo <= element < hi.
+// The set must not be changed during iteration.
+func (s *SortedSet[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		n := s.ceilingNode(lo)
+		if n == nil {
+			return
+		}
+		for ; n != s.leaf && s.cmp(n.value, hi) < 0; n = s.next(n) {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all elements.
+func (s *SortedSet[T]) Clear() {
+	s.root = s.leaf
+}
+
+// Clone returns a new set with same elements. O(N)
+func (s *SortedSet[T]) Clone() *SortedSet[T] {
+	return s.fromSorted(s.values())
+}
+
+// Equal return true if both sets have same elements.
+func (s *SortedSet[T]) Equal(other *SortedSet[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	next, stop := iter.Pull(other.Seq())
+	defer stop()
+	for v := range s.Seq() {
+		o, _ := next()
+		if s.cmp(v, o) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns all elements of both sets. Linear merge, O(N+M).
+func (s *SortedSet[T]) Union(other *SortedSet[T]) *SortedSet[T] {
+	return s.merge(other, true, true, true)
+}
+
+// Intersection returns elements which are in both sets. Linear merge, O(N+M).
+func (s *SortedSet[T]) Intersection(other *SortedSet[T]) *SortedSet[T] {
+	return s.merge(other, false, true, false)
+}
+
+// Difference returns unique elements for that set. Linear merge, O(N+M).
+func (s *SortedSet[T]) Difference(other *SortedSet[T]) *SortedSet[T] {
+	return s.merge(other, true, false, false)
+}
+
+// merge walks both sets in order and keeps elements only in this set, in both sets and only in other.
+func (s *SortedSet[T]) merge(other *SortedSet[T], onlyLeft, both, onlyRight bool) *SortedSet[T] {
+	left := s.values()
+	right := other.values()
+	res := make([]T, 0, len(left)+len(right))
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		c := s.cmp(left[i], right[j])
+		switch {
+		case c < 0:
+			if onlyLeft {
+				res = append(res, left[i])
+			}
+			i++
+		case c > 0:
+			if onlyRight {
+				res = append(res, right[j])
+			}
+			j++
+		default:
+			if both {
+				res = append(res, left[i])
+			}
+			i++
+			j++
+		}
+	}
+	if onlyLeft {
+		res = append(res, left[i:]...)
+	}
+	if onlyRight {
+		res = append(res, right[j:]...)
+	}
+	return s.fromSorted(res)
+}
+
+func (s *SortedSet[T]) values() []T {
+	res := make([]T, 0, s.Len())
+	for v := range s.Seq() {
+		res = append(res, v)
+	}
+	return res
+}
+
+// fromSorted builds a new set with the same comparator from sorted unique values. O(N)
+func (s *SortedSet[T]) fromSorted(values []T) *SortedSet[T] {
+	res := NewSorted(s.cmp)
+	// all nil links of a tree built by halves are on the two lowest levels,
+	// so coloring the lowest level red when it's not full gives a valid red-black tree
+	height := 0
+	for (1<<height)-1 < len(values) {
+		height++
+	}
+	lowestRed := len(values) != (1<<height)-1
+	res.root = res.build(values, res.leaf, 1, height, lowestRed)
+	return res
+}
+
+func (s *SortedSet[T]) build(values []T, parent *sortedNode[T], depth, height int, lowestRed bool) *sortedNode[T] {
+	if len(values) == 0 {
+		return s.leaf
+	}
+	mid := len(values) / 2
+	n := &sortedNode[T]{value: values[mid], parent: parent, size: len(values)}
+	n.red = lowestRed && depth == height
+	n.left = s.build(values[:mid], n, depth+1, height, lowestRed)
+	n.right = s.build(values[mid+1:], n, depth+1, height, lowestRed)
+	return n
+}
@@ -0,0 +1,215 @@
+package set
+
+import (
+	"cmp"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type sortedSetTestSuite struct {
+	suite.Suite
+}
+
+func TestSortedSet(t *testing.T) {
+	suite.Run(t, new(sortedSetTestSuite))
+}
+
+// checkTree validates red-black properties, sizes and parents.
+func (suite *sortedSetTestSuite) checkTree(s *SortedSet[int]) {
+	suite.Require().False(s.root.red, "root is red")
+	suite.Require().False(s.leaf.red, "leaf is red")
+	suite.Require().Equal(0, s.leaf.size)
+	var walk func(n *sortedNode[int]) int
+	walk = func(n *sortedNode[int]) int {
+		if n == s.leaf {
+			return 1
+		}
+		if n.red {
+			suite.Require().False(n.left.red, "red node with red child")
+			suite.Require().False(n.right.red, "red node with red child")
+		}
+		if n.left != s.leaf {
+			suite.Require().Same(n, n.left.parent)
+			suite.Require().Less(n.left.value, n.value)
+		}
+		if n.right != s.leaf {
+			suite.Require().Same(n, n.right.parent)
+			suite.Require().Greater(n.right.value, n.value)
+		}
+		suite.Require().Equal(n.left.size+n.right.size+1, n.size, "wrong size")
+		lh := walk(n.left)
+		rh := walk(n.right)
+		suite.Require().Equal(lh, rh, "black height differs")
+		if n.red {
+			return lh
+		}
+		return lh + 1
+	}
+	walk(s.root)
+}
+
+func (suite *sortedSetTestSuite) TestInsertRemoveRandom() {
+	r := rand.New(rand.NewSource(1))
+	s := NewSorted(cmp.Compare[int])
+	expected := map[int]bool{}
+	for i := 0; i < 2000; i++ {
+		v := r.Intn(300)
+		if r.Intn(3) == 0 {
+			s.Remove(v)
+			delete(expected, v)
+		} else {
+			s.Insert(v)
+			expected[v] = true
+		}
+		if i%50 == 0 {
+			suite.checkTree(s)
+		}
+	}
+	suite.checkTree(s)
+	suite.Require().Equal(len(expected), s.Len())
+	keys := make([]int, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+		suite.Require().True(s.Contains(k))
+	}
+	slices.Sort(keys)
+	suite.Require().Equal(keys, slices.Collect(s.Seq()))
+	slices.Reverse(keys)
+	suite.Require().Equal(keys, slices.Collect(s.ReversedSeq()))
+
+	for k := range expected {
+		s.Remove(k)
+	}
+	suite.checkTree(s)
+	suite.Require().Equal(0, s.Len())
+}
+
+func (suite *sortedSetTestSuite) TestEmpty() {
+	s := NewSorted(cmp.Compare[int])
+	suite.Require().Equal(0, s.Len())
+	suite.Require().False(s.Contains(1))
+	_, exists := s.Min()
+	suite.Require().False(exists)
+	_, exists = s.Max()
+	suite.Require().False(exists)
+	_, exists = s.Floor(1)
+	suite.Require().False(exists)
+	_, exists = s.Ceiling(1)
+	suite.Require().False(exists)
+	_, exists = s.Select(0)
+	suite.Require().False(exists)
+	suite.Require().Equal(0, s.Rank(5))
+	suite.Require().Empty(slices.Collect(s.Seq()))
+	suite.Require().Empty(slices.Collect(s.ReversedSeq()))
+	suite.Require().Empty(slices.Collect(s.Range(0, 10)))
+	s.Remove(1)
+}
+
+func (suite *sortedSetTestSuite) TestQueries() {
+	s := NewSorted(cmp.Compare[int], 50, 10, 40, 20, 30)
+	v, _ := s.Min()
+	suite.Require().Equal(10, v)
+	v, _ = s.Max()
+	suite.Require().Equal(50, v)
+
+	v, exists := s.Floor(35)
+	suite.Require().True(exists)
+	suite.Require().Equal(30, v)
+	v, _ = s.Floor(30)
+	suite.Require().Equal(30, v)
+	_, exists = s.Floor(5)
+	suite.Require().False(exists)
+
+	v, exists = s.Ceiling(35)
+	suite.Require().True(exists)
+	suite.Require().Equal(40, v)
+	v, _ = s.Ceiling(40)
+	suite.Require().Equal(40, v)
+	_, exists = s.Ceiling(55)
+	suite.Require().False(exists)
+
+	suite.Require().Equal(0, s.Rank(10))
+	suite.Require().Equal(2, s.Rank(25))
+	suite.Require().Equal(2, s.Rank(30))
+	suite.Require().Equal(5, s.Rank(100))
+	for k, expected := range []int{10, 20, 30, 40, 50} {
+		v, exists = s.Select(k)
+		suite.Require().True(exists)
+		suite.Require().Equal(expected, v)
+		suite.Require().Equal(k, s.Rank(v))
+	}
+	_, exists = s.Select(5)
+	suite.Require().False(exists)
+	_, exists = s.Select(-1)
+	suite.Require().False(exists)
+
+	suite.Require().Equal([]int{20, 30}, slices.Collect(s.Range(15, 40)))
+	suite.Require().Equal([]int{10, 20}, slices.Collect(s.Range(10, 30)))
+	suite.Require().Empty(slices.Collect(s.Range(41, 50)))
+	suite.Require().Empty(slices.Collect(s.Range(60, 70)))
+	for v := range s.Range(0, 100) {
+		suite.Require().Equal(10, v)
+		break
+	}
+}
+
+func (suite *sortedSetTestSuite) TestComparator() {
+	s := NewSorted(func(a, b int) int { return cmp.Compare(b, a) }, 1, 3, 2)
+	suite.Require().Equal([]int{3, 2, 1}, slices.Collect(s.Seq()))
+}
+
+func (suite *sortedSetTestSuite) TestAlgebra() {
+	left := NewSorted(cmp.Compare[int], 1, 2, 3, 5, 8)
+	right := NewSorted(cmp.Compare[int], 2, 3, 4, 8, 9, 10)
+
+	union := left.Union(right)
+	suite.checkTree(union)
+	suite.Require().Equal([]int{1, 2, 3, 4, 5, 8, 9, 10}, slices.Collect(union.Seq()))
+
+	inter := left.Intersection(right)
+	suite.checkTree(inter)
+	suite.Require().Equal([]int{2, 3, 8}, slices.Collect(inter.Seq()))
+
+	diff := left.Difference(right)
+	suite.checkTree(diff)
+	suite.Require().Equal([]int{1, 5}, slices.Collect(diff.Seq()))
+	suite.Require().Equal([]int{4, 9, 10}, slices.Collect(right.Difference(left).Seq()))
+
+	empty := NewSorted(cmp.Compare[int])
+	suite.Require().Equal(0, empty.Union(empty).Len())
+	suite.Require().True(left.Union(empty).Equal(left))
+	suite.Require().Equal(0, left.Intersection(empty).Len())
+}
+
+func (suite *sortedSetTestSuite) TestBuildSizes() {
+	for n := 0; n < 70; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i
+		}
+		s := NewSorted(cmp.Compare[int]).fromSorted(values)
+		suite.checkTree(s)
+		suite.Require().Equal(n, s.Len())
+		// still valid after changes
+		s.Insert(-1, n+1)
+		s.Remove(n / 2)
+		suite.checkTree(s)
+	}
+}
+
+func (suite *sortedSetTestSuite) TestCloneEqualClear() {
+	s := NewSorted(cmp.Compare[int], 3, 1, 2)
+	c := s.Clone()
+	suite.Require().True(s.Equal(c))
+	c.Insert(4)
+	suite.Require().False(s.Equal(c))
+	c.Remove(4, 3)
+	suite.Require().False(s.Equal(c))
+	s.Clear()
+	suite.Require().Equal(0, s.Len())
+	suite.Require().Equal(2, c.Len())
+	s.Insert(7)
+	suite.Require().Equal([]int{7}, slices.Collect(s.Seq()))
+}
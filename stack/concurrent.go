@@ -0,0 +1,75 @@
+package stack
+
+import (
+	"sync/atomic"
+)
+
+type concurrentNode[T any] struct {
+	value T
+	next  *concurrentNode[T]
+}
+
+// ConcurrentStack is a lock-free stack (Treiber stack) safe for concurrent use.
+// Every Push allocates a new node and nodes are never reused, the garbage collector keeps a node alive
+// while any goroutine holds it, so compare-and-swap can't suffer from the ABA problem.
+// The zero value is an empty stack ready to use.
+type ConcurrentStack[T any] struct {
+	head atomic.Pointer[concurrentNode[T]]
+	len  atomic.Int64
+}
+
+// NewConcurrent creates a new lock-free stack. The first initial value is the top (same as New).
+func NewConcurrent[T any](initial ...T) *ConcurrentStack[T] {
+	s := &ConcurrentStack[T]{}
+	for i := len(initial) - 1; i >= 0; i-- {
+		s.Push(initial[i])
+	}
+	return s
+}
+
+// Push adds a new element to stack.
+func (s *ConcurrentStack[T]) Push(value T) {
+	n := &concurrentNode[T]{value: value}
+	for {
+		n.next = s.head.Load()
+		if s.head.CompareAndSwap(n.next, n) {
+			s.len.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes and return the next element.
+func (s *ConcurrentStack[T]) Pop() (value T, exists bool) {
+	for {
+		head := s.head.Load()
+		if head == nil {
+			return
+		}
+		if s.head.CompareAndSwap(head, head.next) {
+			s.len.Add(-1)
+			return head.value, true
+		}
+	}
+}
+
+// Top returns the first item in the stack without removing it.
+// Another goroutine can pop it right after the call.
+func (s *ConcurrentStack[T]) Top() (value T, exists bool) {
+	head := s.head.Load()
+	if head == nil {
+		return
+	}
+	return head.value, true
+}
+
+// Len returns the number of items in the stack. The value is approximate under concurrent
+// Push and Pop: the counter is updated right after the element is added or removed.
+func (s *ConcurrentStack[T]) Len() int {
+	return max(0, int(s.len.Load()))
+}
+
+// IsEmpty returns 'true' if no elements on the stack.
+func (s *ConcurrentStack[T]) IsEmpty() bool {
+	return s.head.Load() == nil
+}
@@ -0,0 +1,127 @@
+package stack
+
+import (
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+)
+
+type ConcurrentStackTestSuite struct {
+	suite.Suite
+}
+
+func TestRunConcurrentStackSuite(t *testing.T) {
+	suite.Run(t, new(ConcurrentStackTestSuite))
+}
+
+func (s *ConcurrentStackTestSuite) TestPushPop() {
+	var q ConcurrentStack[int]
+	s.Require().True(q.IsEmpty())
+	_, exists := q.Pop()
+	s.Require().False(exists)
+	_, exists = q.Top()
+	s.Require().False(exists)
+
+	q.Push(1)
+	q.Push(2)
+	s.Require().Equal(2, q.Len())
+	v, exists := q.Top()
+	s.Require().True(exists)
+	s.Require().Equal(2, v)
+	v, _ = q.Pop()
+	s.Require().Equal(2, v)
+	v, _ = q.Pop()
+	s.Require().Equal(1, v)
+	s.Require().True(q.IsEmpty())
+	s.Require().Equal(0, q.Len())
+}
+
+func (s *ConcurrentStackTestSuite) TestInitial() {
+	q := NewConcurrent(1, 2, 3)
+	s.Require().Equal(3, q.Len())
+	v, _ := q.Pop()
+	s.Require().Equal(1, v)
+}
+
+func (s *ConcurrentStackTestSuite) TestStress() {
+	const (
+		workers = 8
+		perWork = 2000
+	)
+	q := NewConcurrent[int]()
+	var wg sync.WaitGroup
+	popped := make([][]int, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWork; i++ {
+				q.Push(w*perWork + i)
+				if i%2 == 1 {
+					if v, exists := q.Pop(); exists {
+						popped[w] = append(popped[w], v)
+					}
+				}
+				q.Top()
+				q.Len()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool, workers*perWork)
+	for _, values := range popped {
+		for _, v := range values {
+			s.Require().False(seen[v], "value popped twice")
+			seen[v] = true
+		}
+	}
+	s.Require().Equal(workers*perWork-len(seen), q.Len())
+	for {
+		v, exists := q.Pop()
+		if !exists {
+			break
+		}
+		s.Require().False(seen[v], "value popped twice")
+		seen[v] = true
+	}
+	s.Require().Equal(workers*perWork, len(seen))
+	s.Require().Equal(0, q.Len())
+}
+
+type mutexStack struct {
+	mu sync.Mutex
+	s  *Stack[int]
+}
+
+func (m *mutexStack) Push(v int) {
+	m.mu.Lock()
+	m.s.Push(v)
+	m.mu.Unlock()
+}
+
+func (m *mutexStack) Pop() (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.s.Pop()
+}
+
+func BenchmarkConcurrentStack(b *testing.B) {
+	q := NewConcurrent[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			q.Push(i)
+			q.Pop()
+		}
+	})
+}
+
+func BenchmarkMutexStack(b *testing.B) {
+	q := &mutexStack{s: New[int]()}
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			q.Push(i)
+			q.Pop()
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package stack
+
+import (
+	"slices"
+)
+
+type minMaxEntry[T comparable] struct {
+	value T
+	// minimum and maximum of this element and all elements below it
+	min T
+	max T
+}
+
+// MinMaxStack is a stack which returns the minimum and the maximum of its elements in O(1).
+// Every element keeps the minimum and the maximum of the stack at the moment it was pushed.
+type MinMaxStack[QT comparable] struct {
+	// the top of the stack is the last element
+	data  []minMaxEntry[QT]
+	cmp   func(a, b QT) int
+	limit int
+}
+
+// NewMinMax creates a new stack with the comparator. cmp returns a negative number when a < b,
+// a positive number when a > b and zero otherwise (like cmp.Compare).
+// The first initial value is the top (same as New).
+func NewMinMax[T comparable](cmp func(a, b T) int, initial ...T) *MinMaxStack[T] {
+	q := &MinMaxStack[T]{
+		data: make([]minMaxEntry[T], 0, len(initial)),
+		cmp:  cmp,
+	}
+	for i := len(initial) - 1; i >= 0; i-- {
+		q.push(initial[i])
+	}
+	return q
+}
+
+// Limit returns current maximum number of elements.
+func (q *MinMaxStack[QT]) Limit() int { return q.limit }
+
+// WithLimit sets the maximum number of elements in the stack and returns pointer to itself.
+// limit=0 mens no limits.
+func (q *MinMaxStack[QT]) WithLimit(limit int) *MinMaxStack[QT] {
+	q.limit = limit
+	return q
+}
+
+func (q *MinMaxStack[QT]) push(value QT) {
+	e := minMaxEntry[QT]{value: value, min: value, max: value}
+	if len(q.data) > 0 {
+		prev := q.data[len(q.data)-1]
+		if q.cmp(prev.min, value) < 0 {
+			e.min = prev.min
+		}
+		if q.cmp(prev.max, value) > 0 {
+			e.max = prev.max
+		}
+	}
+	q.data = append(q.data, e)
+}
+
+// Push adds a new element to stack.
+func (q *MinMaxStack[QT]) Push(value QT) bool {
+	if q.limit > 0 && len(q.data)+1 > q.limit {
+		return false
+	}
+	q.push(value)
+	return true
+}
+
+// Pop removes and return the next element.
+func (q *MinMaxStack[QT]) Pop() (value QT, exists bool) {
+	if len(q.data) == 0 {
+		return
+	}
+	last := len(q.data) - 1
+	value = q.data[last].value
+	q.data[last] = minMaxEntry[QT]{}
+	q.data = q.data[:last]
+	return value, true
+}
+
+// Top returns the first item in the stack without removing it.
+func (q *MinMaxStack[QT]) Top() (res QT, exists bool) {
+	if len(q.data) == 0 {
+		return
+	}
+	return q.data[len(q.data)-1].value, true
+}
+
+// Min returns the minimal element of the stack. If the stack is empty returns default value and 'false'.
+func (q *MinMaxStack[QT]) Min() (res QT, exists bool) {
+	if len(q.data) == 0 {
+		return
+	}
+	return q.data[len(q.data)-1].min, true
+}
+
+// Max returns the maximal element of the stack. If the stack is empty returns default value and 'false'.
+func (q *MinMaxStack[QT]) Max() (res QT, exists bool) {
+	if len(q.data) == 0 {
+		return
+	}
+	return q.data[len(q.data)-1].max, true
+}
+
+// IsFull returns 'true' if elements count equal or greater than limit. With limit <= 0 always return false.
+func (q *MinMaxStack[QT]) IsFull() bool {
+	if q.limit <= 0 {
+		return false
+	}
+	// >= because we can change limit in any time
+	return len(q.data) >= q.limit
+}
+
+// IsEmpty returns 'true' if no elements on the stack.
+func (q *MinMaxStack[QT]) IsEmpty() bool {
+	return len(q.data) == 0
+}
+
+// Len returns the number of items in the stack.
+func (q *MinMaxStack[QT]) Len() int {
+	return len(q.data)
+}
+
+// Contains returns 'true' if the element is in the stack.
+func (q *MinMaxStack[QT]) Contains(value QT) bool {
+	for _, e := range q.data {
+		if e.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove removes the first equal element from the stack (from the top).
+// Minimums and maximums of the elements above it are recalculated. O(N)
+func (q *MinMaxStack[QT]) Remove(value QT) {
+	for i := len(q.data) - 1; i >= 0; i-- {
+		if q.data[i].value != value {
+			continue
+		}
+		above := make([]QT, 0, len(q.data)-i-1)
+		for _, e := range q.data[i+1:] {
+			above = append(above, e.value)
+		}
+		clear(q.data[i:])
+		q.data = q.data[:i]
+		for _, v := range above {
+			q.push(v)
+		}
+		return
+	}
+}
+
+// Clear removes all elements from the stack.
+func (q *MinMaxStack[QT]) Clear() {
+	q.data = nil
+}
+
+// Clone returns a new stack with same elements with the same order.
+func (q *MinMaxStack[QT]) Clone() *MinMaxStack[QT] {
+	return &MinMaxStack[QT]{
+		data:  slices.Clone(q.data),
+		cmp:   q.cmp,
+		limit: q.limit,
+	}
+}
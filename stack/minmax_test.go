@@ -0,0 +1,138 @@
+package stack
+
+import (
+	"cmp"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type MinMaxStackTestSuite struct {
+	suite.Suite
+}
+
+func TestRunMinMaxStackSuite(t *testing.T) {
+	suite.Run(t, new(MinMaxStackTestSuite))
+}
+
+func (s *MinMaxStackTestSuite) requireMinMax(q *MinMaxStack[int], expectedMin, expectedMax int) {
+	v, exists := q.Min()
+	s.Require().True(exists)
+	s.Require().Equal(expectedMin, v)
+	v, exists = q.Max()
+	s.Require().True(exists)
+	s.Require().Equal(expectedMax, v)
+}
+
+func (s *MinMaxStackTestSuite) TestEmpty() {
+	q := NewMinMax(cmp.Compare[int])
+	s.Require().True(q.IsEmpty())
+	_, exists := q.Min()
+	s.Require().False(exists)
+	_, exists = q.Max()
+	s.Require().False(exists)
+	_, exists = q.Top()
+	s.Require().False(exists)
+	_, exists = q.Pop()
+	s.Require().False(exists)
+}
+
+func (s *MinMaxStackTestSuite) TestPushPop() {
+	q := NewMinMax(cmp.Compare[int])
+	q.Push(5)
+	s.requireMinMax(q, 5, 5)
+	q.Push(3)
+	s.requireMinMax(q, 3, 5)
+	q.Push(7)
+	s.requireMinMax(q, 3, 7)
+	q.Push(3)
+	s.requireMinMax(q, 3, 7)
+	q.Push(1)
+	s.requireMinMax(q, 1, 7)
+
+	v, _ := q.Pop()
+	s.Require().Equal(1, v)
+	s.requireMinMax(q, 3, 7)
+	q.Pop()
+	s.requireMinMax(q, 3, 7)
+	q.Pop()
+	s.requireMinMax(q, 3, 5)
+	q.Pop()
+	s.requireMinMax(q, 5, 5)
+	v, _ = q.Top()
+	s.Require().Equal(5, v)
+	q.Pop()
+	s.Require().True(q.IsEmpty())
+}
+
+func (s *MinMaxStackTestSuite) TestInitial() {
+	q := NewMinMax(cmp.Compare[int], 4, 9, 2)
+	s.Require().Equal(3, q.Len())
+	v, _ := q.Top()
+	s.Require().Equal(4, v)
+	s.requireMinMax(q, 2, 9)
+	q.Pop()
+	q.Pop()
+	s.requireMinMax(q, 2, 2)
+}
+
+func (s *MinMaxStackTestSuite) TestComparator() {
+	q := NewMinMax(func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+	q.Push("ccc")
+	q.Push("a")
+	q.Push("bbbbb")
+	q.Push("dd")
+	v, _ := q.Min()
+	s.Require().Equal("a", v)
+	v, _ = q.Max()
+	s.Require().Equal("bbbbb", v)
+
+	q = NewMinMax(strings.Compare, "b", "a", "c")
+	v, _ = q.Min()
+	s.Require().Equal("a", v)
+	v, _ = q.Max()
+	s.Require().Equal("c", v)
+}
+
+func (s *MinMaxStackTestSuite) TestLimit() {
+	q := NewMinMax(cmp.Compare[int]).WithLimit(2)
+	s.Require().Equal(2, q.Limit())
+	s.Require().True(q.Push(1))
+	s.Require().True(q.Push(2))
+	s.Require().True(q.IsFull())
+	s.Require().False(q.Push(0))
+	s.requireMinMax(q, 1, 2)
+}
+
+func (s *MinMaxStackTestSuite) TestRemove() {
+	q := NewMinMax(cmp.Compare[int], 3, 1, 9, 1, 5)
+	s.Require().True(q.Contains(9))
+	s.Require().False(q.Contains(10))
+
+	q.Remove(9)
+	s.Require().False(q.Contains(9))
+	s.requireMinMax(q, 1, 5)
+	q.Remove(1)
+	s.requireMinMax(q, 1, 5)
+	q.Remove(1)
+	s.requireMinMax(q, 3, 5)
+	q.Remove(10)
+	s.Require().Equal(2, q.Len())
+	v, _ := q.Top()
+	s.Require().Equal(3, v)
+}
+
+func (s *MinMaxStackTestSuite) TestClearClone() {
+	q := NewMinMax(cmp.Compare[int], 3, 1, 9).WithLimit(5)
+	c := q.Clone()
+	q.Clear()
+	s.Require().True(q.IsEmpty())
+	_, exists := q.Min()
+	s.Require().False(exists)
+	s.Require().Equal(5, c.Limit())
+	s.requireMinMax(c, 1, 9)
+	c.Pop()
+	s.requireMinMax(c, 1, 9)
+	c.Pop()
+	s.requireMinMax(c, 9, 9)
+}
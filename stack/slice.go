@@ -0,0 +1,231 @@
+package stack
+
+import (
+	"iter"
+	"slices"
+)
+
+const (
+	// maxShrinkThreshold is the default and the biggest fill ratio below which the slice is shrunk.
+	// It keeps the fill ratio after shrinking below 1/2, so a few pushes after a shrink
+	// don't grow the slice again (and a few pops after a growth don't shrink it).
+	maxShrinkThreshold = 0.25
+	// minShrinkCap is the capacity which is never shrunk.
+	minShrinkCap = 64
+)
+
+// SliceStack is a stack with the same API as Stack but backed by a slice.
+// Push and Pop are amortized O(1) and don't allocate per element.
+// When the number of elements falls below the shrink threshold part of the capacity the slice is reallocated,
+// so memory is returned after spikes.
+// The zero value is an empty stack ready to use (with the default shrink threshold).
+type SliceStack[QT comparable] struct {
+	// the top of the stack is the last element
+	data  []QT
+	limit int
+	// 0 means maxShrinkThreshold, negative disables shrinking
+	shrinkThreshold float64
+}
+
+// NewSlice creates a new slice-backed stack. The first initial value is the top (same as New).
+func NewSlice[T comparable](initial ...T) *SliceStack[T] {
+	data := slices.Clone(initial)
+	slices.Reverse(data)
+	return &SliceStack[T]{data: data}
+}
+
+// Limit returns current maximum number of elements.
+func (q *SliceStack[QT]) Limit() int { return q.limit }
+
+// WithLimit sets the maximum number of elements in the stack and returns pointer to itself.
+// limit=0 mens no limits.
+func (q *SliceStack[QT]) WithLimit(limit int) *SliceStack[QT] {
+	q.limit = limit
+	return q
+}
+
+// ShrinkThreshold returns current shrink threshold. 0 means shrinking is disabled.
+func (q *SliceStack[QT]) ShrinkThreshold() float64 {
+	switch {
+	case q.shrinkThreshold == 0:
+		return maxShrinkThreshold
+	case q.shrinkThreshold < 0:
+		return 0
+	}
+	return q.shrinkThreshold
+}
+
+// WithShrinkThreshold sets the fill ratio (len/cap) below which the capacity is halved and returns pointer to itself.
+// The default (and threshold=0) is 0.25, negative threshold disables shrinking. The threshold can only be lowered:
+// values above 0.25 are lowered to 0.25, with bigger values the slice can be reallocated again and again
+// when Push and Pop alternate around the threshold.
+func (q *SliceStack[QT]) WithShrinkThreshold(threshold float64) *SliceStack[QT] {
+	q.shrinkThreshold = min(threshold, maxShrinkThreshold)
+	return q
+}
+
+// Pop removes and return the next element.
+func (q *SliceStack[QT]) Pop() (value QT, exists bool) {
+	if len(q.data) == 0 {
+		return
+	}
+	last := len(q.data) - 1
+	value = q.data[last]
+	var zero QT
+	// prevent memory leaks for pointers
+	q.data[last] = zero
+	q.data = q.data[:last]
+	q.shrink()
+	return value, true
+}
+
+// Push adds a new element to stack.
+func (q *SliceStack[QT]) Push(value QT) bool {
+	if q.limit > 0 && len(q.data)+1 > q.limit {
+		return false
+	}
+	q.data = append(q.data, value)
+	return true
+}
+
+// IsFull returns 'true' if elements count equal or greater than limit. With limit <= 0 always return false.
+func (q *SliceStack[QT]) IsFull() bool {
+	if q.limit <= 0 {
+		return false
+	}
+	// >= because we can change limit in any time
+	return len(q.data) >= q.limit
+}
+
+// IsEmpty returns 'true' if no elements on the stack.
+func (q *SliceStack[QT]) IsEmpty() bool {
+	return len(q.data) == 0
+}
+
+// Len returns the number of items in the stack.
+func (q *SliceStack[QT]) Len() int {
+	return len(q.data)
+}
+
+// Cap returns the capacity of the underlying slice.
+func (q *SliceStack[QT]) Cap() int {
+	return cap(q.data)
+}
+
+// Top returns the first item in the stack without removing it.
+func (q *SliceStack[QT]) Top() (res QT, exists bool) {
+	if len(q.data) == 0 {
+		return
+	}
+	return q.data[len(q.data)-1], true
+}
+
+// Contains returns 'true' if the element is in the stack.
+func (q *SliceStack[QT]) Contains(value QT) bool {
+	return slices.Contains(q.data, value)
+}
+
+// Remove removes the first equal element from the stack (from the top).
+func (q *SliceStack[QT]) Remove(value QT) {
+	for i := len(q.data) - 1; i >= 0; i-- {
+		if q.data[i] == value {
+			// slices.Delete clears the tail
+			q.data = slices.Delete(q.data, i, i+1)
+			q.shrink()
+			return
+		}
+	}
+}
+
+// Clear removes all elements from the stack.
+func (q *SliceStack[QT]) Clear() {
+	clear(q.data)
+	q.data = q.data[:0]
+	q.shrink()
+}
+
+// Clone returns a new stack with same elements with the same order.
+func (q *SliceStack[QT]) Clone() *SliceStack[QT] {
+	return &SliceStack[QT]{
+		data:            slices.Clone(q.data),
+		limit:           q.limit,
+		shrinkThreshold: q.shrinkThreshold,
+	}
+}
+
+// shrink halves the capacity while the fill ratio is below the threshold.
+func (q *SliceStack[QT]) shrink() {
+	c := cap(q.data)
+	threshold := q.ShrinkThreshold()
+	if threshold == 0 || c <= minShrinkCap || float64(len(q.data)) >= float64(c)*threshold {
+		return
+	}
+	for c > minShrinkCap && float64(len(q.data)) < float64(c)*threshold {
+		c /= 2
+	}
+	c = max(c, minShrinkCap, len(q.data))
+	data := make([]QT, len(q.data), c)
+	copy(data, q.data)
+	q.data = data
+}
+
+// Seq returns value-iterator from the top to the bottom. The stack must not be changed during iteration.
+func (q *SliceStack[QT]) Seq() iter.Seq[QT] {
+	return func(yield func(QT) bool) {
+		for i := len(q.data) - 1; i >= 0; i-- {
+			if !yield(q.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ReversedSeq returns value-iterator from the bottom to the top. The stack must not be changed during iteration.
+func (q *SliceStack[QT]) ReversedSeq() iter.Seq[QT] {
+	return slices.Values(q.data)
+}
+
+// Seq2 returns depth-value iterator from the top to the bottom. The top has depth 0.
+func (q *SliceStack[QT]) Seq2() iter.Seq2[int, QT] {
+	return func(yield func(int, QT) bool) {
+		for i := len(q.data) - 1; i >= 0; i-- {
+			if !yield(len(q.data)-1-i, q.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// PeekAt returns the element at the depth without removing it. The top has depth 0.
+// If there is no element returns default value and 'false'.
+func (q *SliceStack[QT]) PeekAt(depth int) (value QT, exists bool) {
+	if depth < 0 || depth >= len(q.data) {
+		return
+	}
+	return q.data[len(q.data)-1-depth], true
+}
+
+// PopN removes up to n elements and returns them from the top to the bottom.
+func (q *SliceStack[QT]) PopN(n int) []QT {
+	n = min(n, len(q.data))
+	if n <= 0 {
+		return nil
+	}
+	from := len(q.data) - n
+	res := slices.Clone(q.data[from:])
+	slices.Reverse(res)
+	clear(q.data[from:])
+	q.data = q.data[:from]
+	q.shrink()
+	return res
+}
+
+// PushMany adds elements in the given order, so the last one becomes the top.
+// If all elements don't fit the limit nothing is added and 'false' is returned.
+func (q *SliceStack[QT]) PushMany(values ...QT) bool {
+	if q.limit > 0 && len(q.data)+len(values) > q.limit {
+		return false
+	}
+	q.data = append(q.data, values...)
+	return true
+}
@@ -0,0 +1,246 @@
+package stack
+
+import (
+	"github.com/stretchr/testify/suite"
+	"slices"
+	"testing"
+)
+
+type SliceStackTestSuite struct {
+	suite.Suite
+}
+
+func TestRunSliceStackSuite(t *testing.T) {
+	suite.Run(t, new(SliceStackTestSuite))
+}
+
+func (s *SliceStackTestSuite) TestInit() {
+	q := NewSlice[int]()
+	s.Require().Equal(0, q.Len())
+	s.Require().True(q.IsEmpty())
+
+	q = NewSlice(2, 3, 4)
+	s.Require().Equal(3, q.Len())
+	s.Require().Equal([]int{4, 3, 2}, q.data)
+	v, exists := q.Top()
+	s.Require().True(exists)
+	s.Require().Equal(2, v)
+}
+
+func (s *SliceStackTestSuite) TestPushPop() {
+	q := NewSlice[int]()
+	_, exists := q.Pop()
+	s.Require().False(exists)
+	_, exists = q.Top()
+	s.Require().False(exists)
+
+	for i := 1; i <= 6; i++ {
+		s.Require().True(q.Push(i))
+	}
+	s.Require().Equal(6, q.Len())
+	for i := 6; i >= 1; i-- {
+		v, exists := q.Pop()
+		s.Require().True(exists)
+		s.Require().Equal(i, v)
+	}
+	_, exists = q.Pop()
+	s.Require().False(exists)
+}
+
+func (s *SliceStackTestSuite) TestPushWithLimit() {
+	q := NewSlice[int]().WithLimit(2)
+	s.Require().Equal(2, q.Limit())
+	s.Require().True(q.Push(1))
+	s.Require().False(q.IsFull())
+	s.Require().True(q.Push(2))
+	s.Require().True(q.IsFull())
+	s.Require().False(q.Push(3))
+	s.Require().Equal([]int{1, 2}, q.data)
+	q.Pop()
+	s.Require().True(q.Push(3))
+	s.Require().Equal([]int{1, 3}, q.data)
+
+	// yes, can be. Can't push but can pop
+	q = NewSlice(1, 2, 3).WithLimit(1)
+	s.Require().True(q.IsFull())
+	s.Require().False(q.Push(8))
+	s.Require().Equal(3, q.Len())
+
+	q = NewSlice(1, 2, 3)
+	s.Require().False(q.IsFull())
+}
+
+func (s *SliceStackTestSuite) TestContainsRemove() {
+	q := NewSlice(1, 2, 3, 2)
+	s.Require().True(q.Contains(3))
+	s.Require().False(q.Contains(5))
+
+	q.Remove(2)
+	s.Require().Equal([]int{2, 3, 1}, q.data)
+	q.Remove(5)
+	s.Require().Equal([]int{2, 3, 1}, q.data)
+	q.Remove(2)
+	q.Remove(1)
+	s.Require().Equal([]int{3}, q.data)
+}
+
+func (s *SliceStackTestSuite) TestClearClone() {
+	q := NewSlice(1, 2, 3).WithLimit(5)
+	c := q.Clone()
+	q.Clear()
+	s.Require().True(q.IsEmpty())
+	s.Require().Equal(3, c.Len())
+	s.Require().Equal(5, c.Limit())
+	v, _ := c.Top()
+	s.Require().Equal(1, v)
+}
+
+func (s *SliceStackTestSuite) TestShrink() {
+	q := NewSlice[int]()
+	for i := 0; i < 1024; i++ {
+		q.Push(i)
+	}
+	s.Require().GreaterOrEqual(q.Cap(), 1024)
+	for i := 0; i < 1000; i++ {
+		q.Pop()
+	}
+	s.Require().Less(q.Cap(), 128)
+	s.Require().Equal(24, q.Len())
+	v, _ := q.Top()
+	s.Require().Equal(23, v)
+
+	q.Clear()
+	s.Require().Equal(minShrinkCap, q.Cap())
+
+	// disabled
+	q = NewSlice[int]().WithShrinkThreshold(-1)
+	for i := 0; i < 1024; i++ {
+		q.Push(i)
+	}
+	c := q.Cap()
+	q.Clear()
+	s.Require().Equal(c, q.Cap())
+
+	s.Require().Equal(0.0, q.ShrinkThreshold())
+
+	q = NewSlice[int]().WithShrinkThreshold(0.9)
+	s.Require().Equal(maxShrinkThreshold, q.ShrinkThreshold())
+	s.Require().Equal(0.1, q.WithShrinkThreshold(0.1).ShrinkThreshold())
+	s.Require().Equal(maxShrinkThreshold, q.WithShrinkThreshold(0).ShrinkThreshold())
+
+	// the zero value shrinks with the default threshold
+	var zero SliceStack[int]
+	s.Require().Equal(maxShrinkThreshold, zero.ShrinkThreshold())
+	for i := 0; i < 1024; i++ {
+		zero.Push(i)
+	}
+	for i := 0; i < 1000; i++ {
+		zero.Pop()
+	}
+	s.Require().Less(zero.Cap(), 128)
+}
+
+func (s *SliceStackTestSuite) TestNoThrash() {
+	for _, threshold := range []float64{0.1, 0.25, 0.5, 1} {
+		for _, size := range []int{63, 64, 65, 127, 128, 129, 1000} {
+			q := NewSlice[int]().WithShrinkThreshold(threshold)
+			// the slice is almost full, the next pushes grow it
+			q.data = make([]int, size, size+1)
+			changes := 0
+			c := q.Cap()
+			check := func() {
+				if q.Cap() != c {
+					changes++
+					c = q.Cap()
+				}
+			}
+			for i := 0; i < 1000; i++ {
+				q.Push(1)
+				check()
+				q.Push(2)
+				check()
+				q.Pop()
+				check()
+				q.Pop()
+				check()
+			}
+			s.Require().LessOrEqual(changes, 2, "threshold %v, size %d", threshold, size)
+		}
+	}
+}
+
+func (s *SliceStackTestSuite) TestSeq() {
+	q := NewSlice[int]()
+	s.Require().Empty(slices.Collect(q.Seq()))
+
+	q.PushMany(1, 2, 3)
+	s.Require().Equal([]int{3, 2, 1}, slices.Collect(q.Seq()))
+	s.Require().Equal([]int{1, 2, 3}, slices.Collect(q.ReversedSeq()))
+	for depth, v := range q.Seq2() {
+		s.Require().Equal(3-depth, v)
+		v2, exists := q.PeekAt(depth)
+		s.Require().True(exists)
+		s.Require().Equal(v, v2)
+	}
+	_, exists := q.PeekAt(3)
+	s.Require().False(exists)
+	_, exists = q.PeekAt(-1)
+	s.Require().False(exists)
+}
+
+func (s *SliceStackTestSuite) TestPopNPushMany() {
+	q := NewSlice[int]().WithLimit(4)
+	s.Require().True(q.PushMany(1, 2, 3))
+	s.Require().False(q.PushMany(4, 5))
+	s.Require().Equal([]int{1, 2, 3}, q.data)
+	s.Require().True(q.PushMany(4))
+
+	s.Require().Nil(q.PopN(0))
+	s.Require().Equal([]int{4, 3}, q.PopN(2))
+	s.Require().Equal([]int{2, 1}, q.PopN(5))
+	s.Require().True(q.IsEmpty())
+}
+
+func BenchmarkStackPushPop(b *testing.B) {
+	q := New[int]()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+	}
+	for i := 0; i < b.N; i++ {
+		q.Pop()
+	}
+}
+
+func BenchmarkSliceStackPushPop(b *testing.B) {
+	q := NewSlice[int]()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+	}
+	for i := 0; i < b.N; i++ {
+		q.Pop()
+	}
+}
+
+func BenchmarkStackSpike(b *testing.B) {
+	q := New[int]()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 64; j++ {
+			q.Push(j)
+		}
+		for j := 0; j < 64; j++ {
+			q.Pop()
+		}
+	}
+}
+
+func BenchmarkSliceStackSpike(b *testing.B) {
+	q := NewSlice[int]()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 64; j++ {
+			q.Push(j)
+		}
+		for j := 0; j < 64; j++ {
+			q.Pop()
+		}
+	}
+}
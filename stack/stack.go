@@ -2,12 +2,43 @@
 package stack
 
 import (
+	"iter"
+	"sync/atomic"
+
 	"github.com/HoskeOwl/ggstruct/list"
 )
 
+// OverflowPolicy defines what Push does when the limit is reached.
+type OverflowPolicy int
+
+const (
+	// Reject doesn't add the new element, Push returns 'false'.
+	Reject OverflowPolicy = iota
+	// EvictBottom removes the bottom (oldest) element, so the new one is always added.
+	EvictBottom
+)
+
+// Stack is a LIFO stack. The zero value is an empty stack ready to use.
 type Stack[QT comparable] struct {
-	data  *list.List[QT]
-	limit int
+	data     list.List[QT]
+	limit    int
+	overflow OverflowPolicy
+	// active marks from the outer to the inner, their lengths never decrease
+	marks []mark
+}
+
+// markIDs is shared by all stacks, so a token of one stack is never active in another one.
+var markIDs atomic.Uint64
+
+// Token identifies a mark returned by Mark. It is active only in the stack which made it.
+type Token struct {
+	id uint64
+}
+
+// mark keeps how many elements from the bottom were in the stack at Mark time and are still there.
+type mark struct {
+	id  uint64
+	len int
 }
 
 // Limit returns current maximum number of elements.
@@ -20,10 +51,19 @@ func (q *Stack[QT]) WithLimit(limit int) *Stack[QT] {
 	return q
 }
 
+// Overflow returns current overflow policy.
+func (q *Stack[QT]) Overflow() OverflowPolicy { return q.overflow }
+
+// WithOverflow sets what Push does when the limit is reached and returns pointer to itself.
+func (q *Stack[QT]) WithOverflow(policy OverflowPolicy) *Stack[QT] {
+	q.overflow = policy
+	return q
+}
+
 // New creates a new stack.
 func New[T comparable](initial ...T) *Stack[T] {
 	q := &Stack[T]{
-		data:  list.New[T](initial...),
+		data:  *list.New[T](initial...),
 		limit: 0,
 	}
 	return q
@@ -31,18 +71,55 @@ func New[T comparable](initial ...T) *Stack[T] {
 
 // Pop removes and return the next element.
 func (q *Stack[QT]) Pop() (value QT, exists bool) {
-	return q.data.PopFront()
+	value, exists = q.data.PopFront()
+	q.clampMarks()
+	return
+}
+
+// clampMarks lowers marks after elements were removed from the top.
+// Inner marks are not lower than outer ones, so it stops at the first mark which fits. O(lowered marks)
+func (q *Stack[QT]) clampMarks() {
+	for i := len(q.marks) - 1; i >= 0 && q.marks[i].len > q.data.Len(); i-- {
+		q.marks[i].len = q.data.Len()
+	}
 }
 
-// Push adds a new element to stack.
+// Push adds a new element to stack. If the limit is reached the overflow policy is applied:
+// with Reject returns 'false', with EvictBottom the bottom element is removed.
 func (q *Stack[QT]) Push(value QT) bool {
 	if q.limit > 0 && q.data.Len()+1 > q.limit {
-		return false
+		if q.overflow != EvictBottom {
+			return false
+		}
+		q.evict(1)
 	}
 	q.data.PushFront(value)
 	return true
 }
 
+// PushEvict adds a new element to stack, if the limit is reached removes the bottom element
+// regardless of the overflow policy. Returns the removed element and 'true' if there was one.
+// If the limit was lowered and several elements are removed, the upper of them is returned.
+func (q *Stack[QT]) PushEvict(value QT) (evicted QT, exists bool) {
+	if q.limit > 0 && q.data.Len()+1 > q.limit {
+		evicted, exists = q.evict(1)
+	}
+	q.data.PushFront(value)
+	return
+}
+
+// evict removes bottom elements to fit 'n' new ones in the limit. Returns the last removed.
+func (q *Stack[QT]) evict(n int) (evicted QT, exists bool) {
+	for q.data.Len() > 0 && q.data.Len()+n > q.limit {
+		evicted, exists = q.data.PopBack()
+		// the bottom element is below every mark
+		for i := range q.marks {
+			q.marks[i].len = max(q.marks[i].len-1, 0)
+		}
+	}
+	return
+}
+
 // IsFull returns 'true' if elements count equal or greater than limit. With limit <= 0 always return false.
 func (q *Stack[QT]) IsFull() bool {
 	if q.limit <= 0 {
@@ -78,18 +155,134 @@ func (q *Stack[QT]) Remove(value QT) {
 	if idx == -1 {
 		return
 	}
+	for i := range q.marks {
+		// depth counts from the top, marks count from the bottom
+		if idx >= q.data.Len()-q.marks[i].len {
+			q.marks[i].len--
+		}
+	}
 	q.data.PopAt(idx)
 }
 
 // Clear removes all elements from the stack.
 func (q *Stack[QT]) Clear() {
-	q.data = list.New[QT]()
+	q.data = list.List[QT]{}
+	q.clampMarks()
 }
 
 // Clone returns a new stack with same elements with the same order.
 func (q *Stack[QT]) Clone() *Stack[QT] {
 	return &Stack[QT]{
-		data:  q.data.Clone(),
-		limit: q.limit,
+		data:     *q.data.Clone(),
+		limit:    q.limit,
+		overflow: q.overflow,
+	}
+}
+
+// Seq returns value-iterator from the top to the bottom. The stack must not be changed during iteration.
+func (q *Stack[QT]) Seq() iter.Seq[QT] {
+	return q.data.Seq()
+}
+
+// ReversedSeq returns value-iterator from the bottom to the top. The stack must not be changed during iteration.
+func (q *Stack[QT]) ReversedSeq() iter.Seq[QT] {
+	return q.data.ReversedSeq()
+}
+
+// Seq2 returns depth-value iterator from the top to the bottom. The top has depth 0.
+func (q *Stack[QT]) Seq2() iter.Seq2[int, QT] {
+	return q.data.Seq2()
+}
+
+// PeekAt returns the element at the depth without removing it. The top has depth 0.
+// If there is no element returns default value and 'false'.
+func (q *Stack[QT]) PeekAt(depth int) (value QT, exists bool) {
+	return q.data.PeakAt(depth)
+}
+
+// PopN removes up to n elements and returns them from the top to the bottom.
+func (q *Stack[QT]) PopN(n int) []QT {
+	n = min(n, q.data.Len())
+	if n <= 0 {
+		return nil
+	}
+	res := make([]QT, 0, n)
+	for i := 0; i < n; i++ {
+		v, _ := q.data.PopFront()
+		res = append(res, v)
+	}
+	q.clampMarks()
+	return res
+}
+
+// PushMany adds elements in the given order, so the last one becomes the top.
+// If all elements don't fit the limit: with Reject policy nothing is added and 'false' is returned,
+// with EvictBottom policy bottom elements are removed (including the first given if there are more than the limit).
+func (q *Stack[QT]) PushMany(values ...QT) bool {
+	if q.limit > 0 && q.data.Len()+len(values) > q.limit {
+		if q.overflow != EvictBottom {
+			return false
+		}
+		values = values[max(0, len(values)-q.limit):]
+		q.evict(len(values))
 	}
+	for _, v := range values {
+		q.data.PushFront(v)
+	}
+	return true
+}
+
+// Mark saves the current state. Use Rollback to remove elements pushed after it or Commit to forget it.
+// Marks can be nested. Elements below the mark which are popped, removed or evicted by the overflow policy
+// are taken into account: Rollback removes only elements pushed after the mark.
+func (q *Stack[QT]) Mark() Token {
+	id := markIDs.Add(1)
+	q.marks = append(q.marks, mark{id: id, len: q.data.Len()})
+	return Token{id: id}
+}
+
+func (q *Stack[QT]) markIndex(token Token) int {
+	for i := len(q.marks) - 1; i >= 0; i-- {
+		if q.marks[i].id == token.id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Rollback removes elements pushed after the mark. The mark and all marks made after it are released.
+// Elements popped or evicted below the mark are not restored. Returns 'false' if the token is not active. O(removed)
+func (q *Stack[QT]) Rollback(token Token) bool {
+	idx := q.markIndex(token)
+	if idx < 0 {
+		return false
+	}
+	keep := q.marks[idx].len
+	q.marks = q.marks[:idx]
+	for q.data.Len() > keep {
+		q.data.PopFront()
+	}
+	return true
+}
+
+// RollbackValues same as Rollback but returns removed elements from the top to the bottom.
+func (q *Stack[QT]) RollbackValues(token Token) ([]QT, bool) {
+	idx := q.markIndex(token)
+	if idx < 0 {
+		return nil, false
+	}
+	keep := q.marks[idx].len
+	q.marks = q.marks[:idx]
+	return q.PopN(q.data.Len() - keep), true
+}
+
+// Commit releases the mark and all marks made after it, elements are kept.
+// Returns 'false' if the token is not active.
+func (q *Stack[QT]) Commit(token Token) bool {
+	idx := q.markIndex(token)
+	if idx < 0 {
+		return false
+	}
+	q.marks = q.marks[:idx]
+	return true
 }
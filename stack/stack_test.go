@@ -3,6 +3,7 @@ package stack
 import (
 	"github.com/HoskeOwl/ggstruct/list"
 	"github.com/stretchr/testify/suite"
+	"slices"
 	"testing"
 )
 
@@ -271,3 +272,301 @@ func (s *StackTestSuite) TestIsEmpty() {
 	q.Pop()
 	s.Require().True(q.IsEmpty())
 }
+
+func (s *StackTestSuite) TestSeq() {
+	q := New[int]()
+	s.Require().Empty(slices.Collect(q.Seq()))
+
+	q = New[int]()
+	q.Push(1)
+	q.Push(2)
+	q.Push(3)
+	s.Require().Equal([]int{3, 2, 1}, slices.Collect(q.Seq()))
+	s.Require().Equal([]int{1, 2, 3}, slices.Collect(q.ReversedSeq()))
+	for depth, v := range q.Seq2() {
+		s.Require().Equal(3-depth, v)
+	}
+	// iteration doesn't change the stack
+	s.Require().Equal(3, q.Len())
+
+	for v := range q.Seq() {
+		s.Require().Equal(3, v)
+		break
+	}
+}
+
+func (s *StackTestSuite) TestPeekAt() {
+	q := New[int](1, 2, 3)
+	for depth, expected := range []int{1, 2, 3} {
+		v, exists := q.PeekAt(depth)
+		s.Require().True(exists)
+		s.Require().Equal(expected, v)
+	}
+	_, exists := q.PeekAt(3)
+	s.Require().False(exists)
+	_, exists = q.PeekAt(-1)
+	s.Require().False(exists)
+}
+
+func (s *StackTestSuite) TestPopN() {
+	q := New[int](1, 2, 3, 4)
+	s.Require().Nil(q.PopN(0))
+	s.Require().Nil(q.PopN(-1))
+	s.Require().Equal([]int{1, 2}, q.PopN(2))
+	s.Require().Equal([]int{3, 4}, q.PopN(5))
+	s.Require().True(q.IsEmpty())
+	s.Require().Nil(q.PopN(1))
+}
+
+func (s *StackTestSuite) TestPushMany() {
+	q := New[int]().WithLimit(4)
+	s.Require().True(q.PushMany(1, 2, 3))
+	s.Require().True(q.data.Equal(list.New[int](3, 2, 1)))
+	s.Require().False(q.PushMany(4, 5))
+	s.Require().True(q.data.Equal(list.New[int](3, 2, 1)))
+	s.Require().True(q.PushMany(4))
+	s.Require().True(q.PushMany())
+	s.Require().True(q.data.Equal(list.New[int](4, 3, 2, 1)))
+}
+
+func (s *StackTestSuite) TestPushEvictBottom() {
+	q := New[int]().WithLimit(3).WithOverflow(EvictBottom)
+	s.Require().Equal(EvictBottom, q.Overflow())
+	for i := 1; i <= 5; i++ {
+		s.Require().True(q.Push(i))
+	}
+	s.Require().Equal(3, q.Len())
+	s.Require().True(q.data.Equal(list.New[int](5, 4, 3)))
+
+	// lowered limit
+	q.WithLimit(2)
+	s.Require().True(q.Push(6))
+	s.Require().True(q.data.Equal(list.New[int](6, 5)))
+
+	// no limit
+	q = New[int]().WithOverflow(EvictBottom)
+	q.Push(1)
+	q.Push(2)
+	s.Require().Equal(2, q.Len())
+
+	c := New[int]().WithOverflow(EvictBottom).Clone()
+	s.Require().Equal(EvictBottom, c.Overflow())
+	s.Require().Equal(Reject, New[int]().Overflow())
+}
+
+func (s *StackTestSuite) TestPushEvict() {
+	q := New[int]().WithLimit(2)
+	_, exists := q.PushEvict(1)
+	s.Require().False(exists)
+	_, exists = q.PushEvict(2)
+	s.Require().False(exists)
+	v, exists := q.PushEvict(3)
+	s.Require().True(exists)
+	s.Require().Equal(1, v)
+	s.Require().True(q.data.Equal(list.New[int](3, 2)))
+
+	q = New[int](1, 2, 3, 4).WithLimit(2)
+	v, exists = q.PushEvict(5)
+	s.Require().True(exists)
+	s.Require().Equal(2, v)
+	s.Require().True(q.data.Equal(list.New[int](5, 1)))
+}
+
+func (s *StackTestSuite) TestPushManyEvictBottom() {
+	q := New[int]().WithLimit(3).WithOverflow(EvictBottom)
+	s.Require().True(q.PushMany(1, 2))
+	s.Require().True(q.PushMany(3, 4))
+	s.Require().True(q.data.Equal(list.New[int](4, 3, 2)))
+	s.Require().True(q.PushMany(5, 6, 7, 8))
+	s.Require().True(q.data.Equal(list.New[int](8, 7, 6)))
+}
+
+func (s *StackTestSuite) TestMarkRollback() {
+	q := New[int](1)
+	t := q.Mark()
+	q.Push(2)
+	q.Push(3)
+	s.Require().True(q.Rollback(t))
+	s.Require().True(q.data.Equal(list.New[int](1)))
+	// released
+	s.Require().False(q.Rollback(t))
+	s.Require().False(q.Commit(t))
+
+	t = q.Mark()
+	q.Push(2)
+	q.Push(3)
+	values, ok := q.RollbackValues(t)
+	s.Require().True(ok)
+	s.Require().Equal([]int{3, 2}, values)
+	s.Require().True(q.data.Equal(list.New[int](1)))
+	_, ok = q.RollbackValues(t)
+	s.Require().False(ok)
+
+	// nothing pushed
+	t = q.Mark()
+	values, ok = q.RollbackValues(t)
+	s.Require().True(ok)
+	s.Require().Nil(values)
+
+	// popped below the mark: 1 is not restored, 5 is pushed after the mark
+	t = q.Mark()
+	q.Pop()
+	q.Push(5)
+	s.Require().True(q.Rollback(t))
+	s.Require().True(q.IsEmpty())
+
+	// removed below the mark
+	q.PushMany(1, 2)
+	t = q.Mark()
+	q.Push(3)
+	q.Remove(1)
+	s.Require().True(q.Rollback(t))
+	s.Require().True(q.data.Equal(list.New[int](2)))
+}
+
+func (s *StackTestSuite) TestMarkRollbackEvict() {
+	q := New[int]().WithLimit(3).WithOverflow(EvictBottom)
+	q.PushMany(1, 2, 3)
+	t := q.Mark()
+	q.Push(4)
+	s.Require().True(q.Rollback(t))
+	// 1 was evicted and is not restored, 4 is removed
+	s.Require().True(q.data.Equal(list.New[int](3, 2)))
+
+	// everything below the mark is evicted
+	q = New[int]().WithLimit(2).WithOverflow(EvictBottom)
+	q.PushMany(1, 2)
+	outer := q.Mark()
+	q.Push(3)
+	inner := q.Mark()
+	q.PushMany(4, 5)
+	values, ok := q.RollbackValues(inner)
+	s.Require().True(ok)
+	s.Require().Equal([]int{5, 4}, values)
+	s.Require().True(q.IsEmpty())
+	q.Push(6)
+	s.Require().True(q.Rollback(outer))
+	s.Require().True(q.IsEmpty())
+
+	q = New[int]().WithLimit(2)
+	q.PushMany(1, 2)
+	t = q.Mark()
+	q.PushEvict(3)
+	s.Require().True(q.Rollback(t))
+	s.Require().True(q.data.Equal(list.New[int](2)))
+}
+
+func (s *StackTestSuite) TestNestedMarks() {
+	q := New[int]()
+	outer := q.Mark()
+	q.Push(1)
+	inner := q.Mark()
+	q.Push(2)
+	s.Require().True(q.Commit(inner))
+	s.Require().False(q.Rollback(inner))
+	q.Push(3)
+	s.Require().True(q.data.Equal(list.New[int](3, 2, 1)))
+
+	inner = q.Mark()
+	q.Push(4)
+	inner2 := q.Mark()
+	q.Push(5)
+	s.Require().True(q.Rollback(inner))
+	s.Require().True(q.data.Equal(list.New[int](3, 2, 1)))
+	// inner marks are released with the outer one
+	s.Require().False(q.Commit(inner2))
+
+	inner = q.Mark()
+	q.Push(4)
+	s.Require().True(q.Rollback(outer))
+	s.Require().True(q.IsEmpty())
+	s.Require().False(q.Rollback(inner))
+}
+
+func (s *StackTestSuite) TestMarkClamp() {
+	q := New[int]()
+	q.PushMany(1, 2, 3)
+	outer := q.Mark()
+	q.PushMany(4, 5)
+	inner := q.Mark()
+	q.Push(6)
+	q.PopN(4)
+	s.Require().Equal([]mark{{outer.id, 2}, {inner.id, 2}}, q.marks)
+	q.Push(7)
+	q.Pop()
+	q.Pop()
+	s.Require().Equal([]mark{{outer.id, 1}, {inner.id, 1}}, q.marks)
+	q.Push(8)
+	s.Require().True(q.Rollback(inner))
+	s.Require().True(q.data.Equal(list.New[int](1)))
+}
+
+func (s *StackTestSuite) TestForeignToken() {
+	a := New(1)
+	b := New(1)
+	ta := a.Mark()
+	a.Push(2)
+	b.Mark()
+	b.Push(2)
+	// the token of one stack is never active in another one
+	s.Require().False(b.Rollback(ta))
+	s.Require().False(b.Commit(ta))
+	s.Require().Equal(2, b.Len())
+	s.Require().True(a.Rollback(ta))
+	s.Require().Equal(1, a.Len())
+}
+
+func (s *StackTestSuite) TestZeroValue() {
+	var q Stack[int]
+	s.Require().Equal(0, q.Len())
+	s.Require().True(q.IsEmpty())
+	s.Require().False(q.IsFull())
+	s.Require().Equal(0, q.Limit())
+	s.Require().Equal(Reject, q.Overflow())
+	s.Require().False(q.Contains(1))
+	q.Remove(1)
+	_, exists := q.Top()
+	s.Require().False(exists)
+	_, exists = q.Pop()
+	s.Require().False(exists)
+	_, exists = q.PeekAt(0)
+	s.Require().False(exists)
+	s.Require().Nil(q.PopN(1))
+	s.Require().Empty(slices.Collect(q.Seq()))
+	s.Require().Empty(slices.Collect(q.ReversedSeq()))
+	for range q.Seq2() {
+		s.Fail("should not be reached")
+	}
+	s.Require().True(q.Clone().IsEmpty())
+	q.Clear()
+	s.Require().True(q.Push(1))
+	s.Require().True(q.Contains(1))
+
+	var q2 Stack[int]
+	s.Require().True(q2.PushMany(1, 2))
+	v, _ := q2.Pop()
+	s.Require().Equal(2, v)
+
+	var q3 Stack[int]
+	q3.WithLimit(1).WithOverflow(EvictBottom)
+	q3.Push(1)
+	q3.Push(2)
+	s.Require().Equal(1, q3.Len())
+
+	var q4 Stack[int]
+	q4.WithLimit(1)
+	q4.PushEvict(1)
+	v, exists = q4.PushEvict(2)
+	s.Require().True(exists)
+	s.Require().Equal(1, v)
+
+	var q5 Stack[int]
+	t := q5.Mark()
+	q5.Push(1)
+	s.Require().True(q5.Rollback(t))
+	t = q5.Mark()
+	s.Require().True(q5.Commit(t))
+	t = q5.Mark()
+	_, ok := q5.RollbackValues(t)
+	s.Require().True(ok)
+}